@@ -27,12 +27,7 @@ func Connectivity() {
 		panic(err)
 	}
 
-	index := client.Index(model.IndexLocator{
-		CollectionLocator: model.CollectionLocator{
-			CollectionName: os.Getenv("VIKINGDB_COLLECTION"),
-		},
-		IndexName: os.Getenv("VIKINGDB_INDEX"),
-	})
+	index := client.Index(model.NewIndexLocator(os.Getenv("VIKINGDB_COLLECTION"), os.Getenv("VIKINGDB_INDEX")))
 
 	resp, err := index.SearchByRandom(context.Background(), model.SearchByRandomRequest{
 		SearchBase: model.SearchBase{Limit: intPtr(1)},
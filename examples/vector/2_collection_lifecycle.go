@@ -24,11 +24,8 @@ func CollectionLifecycle() {
 		panic(err)
 	}
 
-	collectionClient := client.Collection(model.CollectionLocator{CollectionName: os.Getenv("VIKINGDB_COLLECTION")})
-	indexClient := client.Index(model.IndexLocator{
-		CollectionLocator: model.CollectionLocator{CollectionName: os.Getenv("VIKINGDB_COLLECTION")},
-		IndexName:         os.Getenv("VIKINGDB_INDEX"),
-	})
+	collectionClient := client.Collection(model.NewCollectionLocator(os.Getenv("VIKINGDB_COLLECTION")))
+	indexClient := client.Index(model.NewIndexLocator(os.Getenv("VIKINGDB_COLLECTION"), os.Getenv("VIKINGDB_INDEX")))
 
 	ctx := context.Background()
 
@@ -23,11 +23,8 @@ func IndexSearchMultiModal() {
 		panic(err)
 	}
 
-	collectionClient := client.Collection(model.CollectionLocator{CollectionName: os.Getenv("VIKINGDB_COLLECTION")})
-	indexClient := client.Index(model.IndexLocator{
-		CollectionLocator: model.CollectionLocator{CollectionName: os.Getenv("VIKINGDB_COLLECTION")},
-		IndexName:         os.Getenv("VIKINGDB_INDEX"),
-	})
+	collectionClient := client.Collection(model.NewCollectionLocator(os.Getenv("VIKINGDB_COLLECTION")))
+	indexClient := client.Index(model.NewIndexLocator(os.Getenv("VIKINGDB_COLLECTION"), os.Getenv("VIKINGDB_INDEX")))
 
 	ctx := context.Background()
 
@@ -65,10 +62,10 @@ func IndexSearchMultiModal() {
 	time.Sleep(3 * time.Second)
 
 	filter := model.MapStr{
-		"op":    "range",
-		"field": "paragraph",
-		"gte":   baseParagraph,
-		"lte":   baseParagraph + 1,
+		model.FilterKeyOp:    model.OpRange,
+		model.FilterKeyField: "paragraph",
+		model.CondGte:        baseParagraph,
+		model.CondLte:        baseParagraph + 1,
 	}
 
 	searchReq := model.SearchByMultiModalRequest{
@@ -25,11 +25,8 @@ func IndexSearchVector(collection, index string) {
 		panic(err)
 	}
 
-	collectionClient := client.Collection(model.CollectionLocator{CollectionName: collection})
-	indexClient := client.Index(model.IndexLocator{
-		CollectionLocator: model.CollectionLocator{CollectionName: collection},
-		IndexName:         index,
-	})
+	collectionClient := client.Collection(model.NewCollectionLocator(collection))
+	indexClient := client.Index(model.NewIndexLocator(collection, index))
 	embeddingClient := client.Embedding()
 
 	ctx := context.Background()
@@ -116,10 +113,10 @@ func IndexSearchVector(collection, index string) {
 	}
 
 	filter := model.MapStr{
-		"op":    "range",
-		"field": "paragraph",
-		"gte":   baseParagraph,
-		"lt":    baseParagraph + int64(len(chapters)),
+		model.FilterKeyOp:    model.OpRange,
+		model.FilterKeyField: "paragraph",
+		model.CondGte:        baseParagraph,
+		model.CondLt:         baseParagraph + int64(len(chapters)),
 	}
 	searchReq := model.SearchByVectorRequest{
 		SearchBase: model.SearchBase{
@@ -129,7 +126,7 @@ func IndexSearchVector(collection, index string) {
 			Limit:        intPtr(3),
 			OutputFields: []string{"title", "score", "paragraph"},
 		},
-		DenseVector: toFloat64(queryResp.Result.Data[0].DenseVectors),
+		DenseVectorF32: queryResp.Result.Data[0].DenseVectors,
 	}
 
 	searchResp, err := indexClient.SearchByVector(ctx, searchReq)
@@ -142,4 +139,21 @@ func IndexSearchVector(collection, index string) {
 	for _, hit := range searchResp.Result.Data {
 		log.Printf("SearchByVector hit id=%v title=%v score=%v", hit.ID, hit.Fields["title"], hit.Fields["score"])
 	}
+
+	// Two-stage retrieval: rerun the query against a candidate allow-list that skips the top hit.
+	// IDsIn is ANDed with Filter, so only candidates inside the paragraph window are ranked.
+	candidates := make([]interface{}, 0, len(searchResp.Result.Data))
+	for _, hit := range searchResp.Result.Data[1:] {
+		candidates = append(candidates, hit.ID)
+	}
+	searchReq.Advance = &model.SearchAdvance{IDsIn: candidates}
+	candidateResp, err := indexClient.SearchByVector(ctx, searchReq)
+	if err != nil {
+		panic(err)
+	}
+	if candidateResp != nil && candidateResp.Result != nil {
+		for _, hit := range candidateResp.Result.Data {
+			log.Printf("SearchByVector candidate hit id=%v title=%v", hit.ID, hit.Fields["title"])
+		}
+	}
 }
@@ -23,11 +23,8 @@ func IndexSearchKeywords() {
 		panic(err)
 	}
 
-	collectionClient := client.Collection(model.CollectionLocator{CollectionName: os.Getenv("VIKINGDB_COLLECTION")})
-	indexClient := client.Index(model.IndexLocator{
-		CollectionLocator: model.CollectionLocator{CollectionName: os.Getenv("VIKINGDB_COLLECTION")},
-		IndexName:         os.Getenv("VIKINGDB_INDEX"),
-	})
+	collectionClient := client.Collection(model.NewCollectionLocator(os.Getenv("VIKINGDB_COLLECTION")))
+	indexClient := client.Index(model.NewIndexLocator(os.Getenv("VIKINGDB_COLLECTION"), os.Getenv("VIKINGDB_INDEX")))
 
 	ctx := context.Background()
 
@@ -65,21 +62,17 @@ func IndexSearchKeywords() {
 	time.Sleep(3 * time.Second)
 
 	filter := model.MapStr{
-		"op":    "range",
-		"field": "paragraph",
-		"gte":   baseParagraph,
-		"lt":    baseParagraph + 2,
-	}
-	keywordsReq := model.SearchByKeywordsRequest{
-		Keywords: []string{"playbook"},
-		SearchBase: model.SearchBase{
-			RecallBase: model.RecallBase{
-				Filter: filter,
-			},
-			Limit:        intPtr(2),
-			OutputFields: []string{"title", "score"},
-		},
+		model.FilterKeyOp:    model.OpRange,
+		model.FilterKeyField: "paragraph",
+		model.CondGte:        baseParagraph,
+		model.CondLt:         baseParagraph + 2,
 	}
+	keywordsReq := vector.NewSearch().
+		Filter(filter).
+		Limit(2).
+		Output("title", "score").
+		Keywords("playbook").
+		Build()
 
 	searchResp, err := indexClient.SearchByKeywords(ctx, keywordsReq)
 	if err != nil {
@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector"
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+// IndexSearchScalar orders filtered documents by a scalar field instead of similarity.
+func IndexSearchScalar() {
+	client, err := vector.New(
+		vector.AuthIAM(os.Getenv("VIKINGDB_AK"), os.Getenv("VIKINGDB_SK")),
+		vector.WithEndpoint("https://"+os.Getenv("VIKINGDB_HOST")),
+		vector.WithRegion(os.Getenv("VIKINGDB_REGION")),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	collectionClient := client.Collection(model.NewCollectionLocator(os.Getenv("VIKINGDB_COLLECTION")))
+	indexClient := client.Index(model.NewIndexLocator(os.Getenv("VIKINGDB_COLLECTION"), os.Getenv("VIKINGDB_INDEX")))
+
+	ctx := context.Background()
+
+	baseParagraph := time.Now().UnixNano() % 1_000_000
+	documents := []model.MapStr{
+		{
+			"title":     "Scalar warm-up",
+			"paragraph": baseParagraph,
+			"score":     64.0,
+			"text":      "Lowest score of the scalar demonstration.",
+		},
+		{
+			"title":     "Scalar peak",
+			"paragraph": baseParagraph + 1,
+			"score":     97.0,
+			"text":      "Highest score of the scalar demonstration.",
+		},
+		{
+			"title":     "Scalar middle",
+			"paragraph": baseParagraph + 2,
+			"score":     81.0,
+			"text":      "Middle score of the scalar demonstration.",
+		},
+	}
+
+	for _, doc := range documents {
+		upsertReq := model.UpsertDataRequest{
+			WriteDataBase: model.WriteDataBase{
+				Data: []model.MapStr{doc},
+			},
+		}
+		resp, upsertErr := collectionClient.Upsert(ctx, upsertReq)
+		if upsertErr != nil {
+			panic(upsertErr)
+		}
+		if resp != nil {
+			log.Printf("Upsert request_id=%s", resp.RequestID)
+		}
+	}
+
+	time.Sleep(3 * time.Second)
+
+	// The filter scopes the search to the documents written above; Field and Order rank them.
+	filter := model.MapStr{
+		model.FilterKeyOp:    model.OpRange,
+		model.FilterKeyField: "paragraph",
+		model.CondGte:        baseParagraph,
+		model.CondLt:         baseParagraph + int64(len(documents)),
+	}
+	scalarReq := model.SearchByScalarRequest{
+		SearchBase: vector.NewSearch().
+			Filter(filter).
+			Limit(len(documents)).
+			Output("title", "score", "paragraph").
+			Base(),
+		Field: stringPtr("score"),
+		Order: model.ScalarOrderDesc,
+	}
+
+	searchResp, err := indexClient.SearchByScalar(ctx, scalarReq)
+	if err != nil {
+		panic(err)
+	}
+	if searchResp == nil || searchResp.Result == nil || len(searchResp.Result.Data) == 0 {
+		panic("SearchByScalar returned no hits")
+	}
+
+	for _, item := range searchResp.Result.Data {
+		log.Printf("SearchByScalar hit id=%v title=%v score=%v paragraph=%v", item.ID, item.Fields["title"], item.Fields["score"], item.Fields["paragraph"])
+	}
+}
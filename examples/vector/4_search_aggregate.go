@@ -24,11 +24,8 @@ func IndexSearchAggregate() {
 		panic(err)
 	}
 
-	collectionClient := client.Collection(model.CollectionLocator{CollectionName: os.Getenv("VIKINGDB_COLLECTION")})
-	indexClient := client.Index(model.IndexLocator{
-		CollectionLocator: model.CollectionLocator{CollectionName: os.Getenv("VIKINGDB_COLLECTION")},
-		IndexName:         os.Getenv("VIKINGDB_INDEX"),
-	})
+	collectionClient := client.Collection(model.NewCollectionLocator(os.Getenv("VIKINGDB_COLLECTION")))
+	indexClient := client.Index(model.NewIndexLocator(os.Getenv("VIKINGDB_COLLECTION"), os.Getenv("VIKINGDB_INDEX")))
 
 	ctx := context.Background()
 
@@ -67,7 +64,7 @@ func IndexSearchAggregate() {
 
 	aggReq := model.AggRequest{
 		Field: stringPtr("paragraph"),
-		Op:    "count",
+		Op:    model.AggOpCount,
 		Cond:  model.MapStr{"gte": baseParagraph},
 	}
 
@@ -80,5 +77,5 @@ func IndexSearchAggregate() {
 	}
 
 	aggJSON, _ := json.Marshal(aggResp.Result.Agg)
-	log.Printf("Aggregate request_id=%s agg=%s", aggResp.RequestID, string(aggJSON))
+	log.Printf("Aggregate request_id=%s count=%d agg=%s", aggResp.RequestID, aggResp.Result.Count, string(aggJSON))
 }
@@ -8,6 +8,7 @@ func main() {
 	IndexSearchMultiModal()
 	IndexSearchVector("vector", "vector_index")
 	IndexSearchKeywords()
+	IndexSearchScalar()
 	IndexSearchAggregate()
 	EmbeddingMultiModal()
 	EmbeddingDenseSparse()
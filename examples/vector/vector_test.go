@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"testing"
 	"time"
@@ -54,6 +55,55 @@ func TestScenarioConnectivity(t *testing.T) {
 	require.NotNil(t, embeddingClient)
 }
 
+// Scenario 1.1 – Addressing by Resource ID
+//
+// Collections can be addressed by ResourceID instead of CollectionName; exactly one of the two must be set.
+// Export VIKINGDB_RESOURCE_ID (the collection's resource id) to run this guide:
+//  1. Reject locators that set both identifiers before any request is sent.
+//  2. Run a random search and an index fetch through a resource-id index locator.
+//  3. Fetch the same document through a resource-id collection locator.
+func TestScenarioResourceIDAddressing(t *testing.T) {
+	env := requireEnv(t)
+	resourceID := os.Getenv("VIKINGDB_RESOURCE_ID")
+	if resourceID == "" {
+		t.Skip("missing VIKINGDB_RESOURCE_ID")
+	}
+
+	client := mustNewClient(t, env)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ambiguous := client.Collection(model.NewCollectionLocator(env.Collection).WithResourceID(resourceID))
+	_, err := ambiguous.Fetch(ctx, model.FetchDataInCollectionRequest{IDs: []interface{}{1}})
+	require.Error(t, err, "locators with both collection name and resource id must be rejected")
+
+	byIDIndex := client.Index(model.IndexLocator{
+		CollectionLocator: model.CollectionLocator{ResourceID: resourceID},
+		IndexName:         env.Index,
+	})
+	limit := 1
+	randomResp, err := byIDIndex.SearchByRandom(ctx, model.SearchByRandomRequest{
+		SearchBase: model.SearchBase{Limit: &limit},
+	})
+	require.NoError(t, err, "SearchByRandom by resource id failed")
+	require.NotNil(t, randomResp.Result)
+	if len(randomResp.Result.Data) == 0 {
+		t.Skip("index is empty; nothing to fetch by resource id")
+	}
+	id := randomResp.Result.Data[0].ID
+
+	indexFetch, err := byIDIndex.Fetch(ctx, model.FetchDataInIndexRequest{IDs: []interface{}{id}})
+	require.NoError(t, err, "index Fetch by resource id failed")
+	require.NotNil(t, indexFetch.Result)
+	require.Len(t, indexFetch.Result.Items, 1)
+
+	byIDCollection := client.Collection(model.CollectionLocator{ResourceID: resourceID})
+	collectionFetch, err := byIDCollection.Fetch(ctx, model.FetchDataInCollectionRequest{IDs: []interface{}{id}})
+	require.NoError(t, err, "collection Fetch by resource id failed")
+	require.NotNil(t, collectionFetch.Result)
+	require.Len(t, collectionFetch.Result.Items, 1)
+}
+
 // Scenario 2 – Collection Lifecycle
 //
 // Demonstrates how to manage data within a VikingDB collection:
@@ -213,6 +263,75 @@ func TestScenarioIndexSearchMultiModal(t *testing.T) {
 	require.Equal(t, 2, len(searchResp.Result.Data), "score filter should surface the two advanced chapters")
 }
 
+// Scenario 3.1.1 – Combined Text and Image Query
+//
+// A multimodal index (one whose vectorize model accepts images) can be queried with a photo and a
+// description at once. Export VIKINGDB_MULTIMODAL_COLLECTION, VIKINGDB_MULTIMODAL_INDEX, and
+// VIKINGDB_IMAGE_URL to run this guide:
+//  1. Search with the text alone, then with the text and the image in one request.
+//  2. The server embeds the combined input into a single query vector; there is no per-modality weight.
+//  3. To favour one modality, fuse the separate text and image searches with WeightedReciprocalRankFusion.
+func TestScenarioIndexSearchTextAndImage(t *testing.T) {
+	env := requireEnv(t)
+	collection := os.Getenv("VIKINGDB_MULTIMODAL_COLLECTION")
+	index := os.Getenv("VIKINGDB_MULTIMODAL_INDEX")
+	imageURL := os.Getenv("VIKINGDB_IMAGE_URL")
+	if collection == "" || index == "" || imageURL == "" {
+		t.Skip("missing VIKINGDB_MULTIMODAL_COLLECTION, VIKINGDB_MULTIMODAL_INDEX, or VIKINGDB_IMAGE_URL")
+	}
+
+	indexClient := mustNewClient(t, env).Index(model.NewIndexLocator(collection, index))
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	description := "a product matching this photo"
+	limit := 5
+
+	textOnly, err := indexClient.SearchByMultiModal(ctx, model.SearchByMultiModalRequest{
+		SearchBase: model.SearchBase{Limit: &limit},
+		Text:       &description,
+	})
+	require.NoError(t, err, "text-only SearchByMultiModal failed")
+	require.NotNil(t, textOnly.Result)
+
+	imageOnly, err := indexClient.SearchByMultiModal(ctx, model.SearchByMultiModalRequest{
+		SearchBase: model.SearchBase{Limit: &limit},
+		Image:      imageURL,
+	})
+	require.NoError(t, err, "image-only SearchByMultiModal failed")
+	require.NotNil(t, imageOnly.Result)
+
+	combined, err := indexClient.SearchByMultiModal(ctx, model.SearchByMultiModalRequest{
+		SearchBase: model.SearchBase{Limit: &limit},
+		Text:       &description,
+		Image:      imageURL,
+	})
+	require.NoError(t, err, "combined text+image SearchByMultiModal failed")
+	require.NotNil(t, combined.Result)
+	for idx, hit := range combined.Result.Data {
+		log.Printf("SearchByMultiModal text+image request_id=%s hit[%d]=id:%v score:%v", combined.RequestID, idx, hit.ID, hit.Score)
+	}
+
+	// Client-side fusion lets the image ranking count twice as much as the text ranking.
+	fused, err := model.WeightedReciprocalRankFusion(model.DefaultRRFK, []float64{2, 1}, imageOnly.Result.Data, textOnly.Result.Data)
+	require.NoError(t, err)
+	imageIDs := make(map[interface{}]bool, len(imageOnly.Result.Data))
+	for _, hit := range imageOnly.Result.Data {
+		imageIDs[model.IDKey(hit.ID)] = true
+	}
+	for idx, hit := range fused {
+		log.Printf("Fused image-weighted hit[%d]=id:%v rrf:%v", idx, hit.ID, hit.Score)
+		if idx > 0 {
+			require.LessOrEqual(t, hit.Score, fused[idx-1].Score, "fused hits should be ordered by fused score")
+		}
+	}
+	if len(imageOnly.Result.Data) > 0 {
+		// With limit hits per list, any image hit scores at least 2/(k+limit), more than the 1/(k+1)
+		// a hit found only by text can reach, so the top fused hit comes from the image ranking.
+		require.True(t, imageIDs[model.IDKey(fused[0].ID)], "image weighting should put an image hit first")
+	}
+}
+
 // Scenario 3.2 – Vector Retrieval With Embeddings
 //
 // This guide mirrors the Python quickstart: write chapters that include a dense vector field,
@@ -293,6 +412,29 @@ func TestScenarioIndexSearchVector(t *testing.T) {
 		}
 	}
 	require.Containsf(t, titles, targetChapter.Title, "expected %q to appear in vector search results", targetChapter.Title)
+
+	// 5. Two-stage retrieval: restrict the same query to a candidate allow-list that excludes the
+	// target chapter. IDsIn, Filter, and the dense vector are ANDed, so the target must disappear.
+	candidates := make([]interface{}, 0, len(hits))
+	allowed := make(map[string]struct{}, len(hits))
+	for _, hit := range hits {
+		if hit.Fields["title"] == targetChapter.Title {
+			continue
+		}
+		candidates = append(candidates, hit.ID)
+		allowed[fmt.Sprint(hit.ID)] = struct{}{}
+	}
+	require.NotEmpty(t, candidates, "expected other chapters besides the target")
+
+	searchReq.Advance = &model.SearchAdvance{IDsIn: candidates}
+	candidateResp, err := indexClient.SearchByVector(ctx, searchReq)
+	require.NoError(t, err, "candidate vector search failed")
+	require.NotNil(t, candidateResp.Result, "candidate vector search should return results")
+	for _, hit := range candidateResp.Result.Data {
+		_, ok := allowed[fmt.Sprint(hit.ID)]
+		require.Truef(t, ok, "hit %v escaped the ids_in allow-list", hit.ID)
+		require.NotEqual(t, targetChapter.Title, hit.Fields["title"])
+	}
 }
 
 // Scenario 3_3 – Search Extensions & Analytics
@@ -360,6 +502,67 @@ func TestScenarioSearchKeywords(t *testing.T) {
 	log.Printf("SearchByKeywords request_id=%s hits=%d", keyResp.RequestID, len(keyResp.Result.Data))
 }
 
+// Scenario 3.4 – Scalar Ordering
+//
+// SearchByScalar skips similarity entirely: it returns the documents matching the filter ordered by a
+// scalar field. Here the session's chapters are ranked by score, highest first, and every hit must
+// fall inside the session's paragraph range to show the filter is honored.
+func TestScenarioIndexSearchScalar(t *testing.T) {
+	env := requireEnv(t)
+	sdk := mustNewClient(t, env)
+	collectionClient := sdk.Collection(collectionBase(env))
+	indexClient := sdk.Index(indexBase(env))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	sessionTag := newSessionTag("index-scalar")
+	baseParagraph := time.Now().UnixNano() % 1_000_000
+	chapters := buildStoryChapters(sessionTag, baseParagraph)
+
+	// only one record at a time.
+	for _, chapter := range chaptersToUpsert(chapters) {
+		upsertReq := model.UpsertDataRequest{
+			WriteDataBase: model.WriteDataBase{
+				Data: []model.MapStr{chapter},
+			},
+		}
+		upsertResp, err := collectionClient.Upsert(ctx, upsertReq)
+		require.NoError(t, err, "upsert failed")
+		require.NotNil(t, upsertResp.Result)
+	}
+
+	time.Sleep(5 * time.Second)
+
+	scoreField := "score"
+	scalarReq := model.SearchByScalarRequest{
+		SearchBase: vector.NewSearch().
+			Filter(sessionParagraphBounds(baseParagraph, len(chapters))).
+			Limit(len(chapters)).
+			Output("title", "score", "paragraph").
+			Base(),
+		Field: &scoreField,
+		Order: model.ScalarOrderDesc,
+	}
+	scalarResp, err := indexClient.SearchByScalar(ctx, scalarReq)
+	require.NoError(t, err, "SearchByScalar failed")
+	require.NotNil(t, scalarResp.Result)
+	require.Len(t, scalarResp.Result.Data, len(chapters), "filter should match exactly this session's chapters")
+
+	previous := math.Inf(1)
+	for _, item := range scalarResp.Result.Data {
+		paragraph := int64(requireFloat64Field(t, item.Fields, "paragraph"))
+		require.GreaterOrEqual(t, paragraph, baseParagraph, "hit outside the filtered paragraph range")
+		require.Less(t, paragraph, baseParagraph+int64(len(chapters)), "hit outside the filtered paragraph range")
+		require.Contains(t, requireStringField(t, item.Fields, "title"), sessionTag)
+
+		score := requireNumberField(t, item.Fields, "score")
+		require.LessOrEqual(t, score, previous, "hits should be ordered by score descending")
+		previous = score
+	}
+	log.Printf("SearchByScalar request_id=%s hits=%d", scalarResp.RequestID, len(scalarResp.Result.Data))
+}
+
 // Scenario 4 – Search Aggregations
 //
 // Building on the Atlas journey, discover counts, group by paragraphs:
@@ -624,19 +827,11 @@ func sharedClientOptions(env guideEnv) []vector.ClientOption {
 }
 
 func collectionBase(env guideEnv) model.CollectionLocator {
-	return model.CollectionLocator{
-		CollectionName: env.Collection,
-		ProjectName:    "",
-	}
+	return model.NewCollectionLocator(env.Collection)
 }
 
 func indexBase(env guideEnv) model.IndexLocator {
-	return model.IndexLocator{
-		CollectionLocator: model.CollectionLocator{
-			CollectionName: env.Collection,
-		},
-		IndexName: env.Index,
-	}
+	return model.NewIndexLocator(env.Collection, env.Index)
 }
 
 // storyChapter captures the fields we write for each guide document.
@@ -763,26 +958,26 @@ func findChapter(t *testing.T, chapters []*storyChapter, key string) *storyChapt
 // sessionParagraphBounds returns a simple paragraph range filter to scope the session's documents.
 func sessionParagraphBounds(base int64, count int) model.MapStr {
 	return model.MapStr{
-		"op":    "range",
-		"field": "paragraph",
-		"gte":   base,
-		"lt":    base + int64(count),
+		model.FilterKeyOp:    model.OpRange,
+		model.FilterKeyField: "paragraph",
+		model.CondGte:        base,
+		model.CondLt:         base + int64(count),
 	}
 }
 
 func mustFilter(field string, conds ...interface{}) model.MapStr {
 	return model.MapStr{
-		"op":    "must",
-		"field": field,
-		"conds": conds,
+		model.FilterKeyOp:    model.OpMust,
+		model.FilterKeyField: field,
+		model.FilterKeyConds: conds,
 	}
 }
 
 func scoreAtLeastFilter(min float64) model.MapStr {
 	return model.MapStr{
-		"op":    "range",
-		"field": "score",
-		"gt":    min,
+		model.FilterKeyOp:    model.OpRange,
+		model.FilterKeyField: "score",
+		model.CondGt:         min,
 	}
 }
 
@@ -802,8 +997,8 @@ func andFilters(conds ...model.MapStr) model.MapStr {
 		return filtered[0]
 	default:
 		return model.MapStr{
-			"op":    "and",
-			"conds": filtered,
+			model.FilterKeyOp:    model.OpAnd,
+			model.FilterKeyConds: filtered,
 		}
 	}
 }
@@ -894,6 +1089,19 @@ func requireFloat64Field(t *testing.T, fields map[string]interface{}, key string
 	}
 }
 
+// requireNumberField is requireFloat64Field for fractional values: json.Number fields keep their
+// fraction instead of being truncated to an integer.
+func requireNumberField(t *testing.T, fields map[string]interface{}, key string) float64 {
+	t.Helper()
+
+	if v, ok := fields[key].(json.Number); ok {
+		vf, err := v.Float64()
+		require.NoErrorf(t, err, "field %q is not a number: %v", key, v)
+		return vf
+	}
+	return requireFloat64Field(t, fields, key)
+}
+
 func requireStringField(t *testing.T, fields map[string]interface{}, key string) string {
 	t.Helper()
 
@@ -1,9 +1,19 @@
 module github.com/volcengine/vikingdb-go-sdk
 
-go 1.14
+go 1.18
 
 require (
 	github.com/joho/godotenv v1.5.1
 	github.com/stretchr/testify v1.7.0
 	github.com/volcengine/volc-sdk-golang v1.0.215
 )
+
+require (
+	github.com/cenkalti/backoff/v4 v4.1.2 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.3.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.12.0 // indirect
+	golang.org/x/text v0.11.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+)
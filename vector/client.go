@@ -6,9 +6,15 @@ package vector
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/volcengine/vikingdb-go-sdk/vector/model"
 	"github.com/volcengine/vikingdb-go-sdk/vector/utils"
@@ -16,6 +22,15 @@ import (
 
 const requestIDHeader = "X-Tt-Logid"
 
+// ErrDryRun is returned by every operation when the client runs in dry-run mode.
+var ErrDryRun = errors.New("vikingdb: dry run, request not sent")
+
+// redactedHeaders lists headers whose values are masked in dry-run output.
+var redactedHeaders = map[string]struct{}{
+	"Authorization":    {},
+	"X-Security-Token": {},
+}
+
 type authKind int
 
 const (
@@ -95,6 +110,15 @@ type transport struct {
 	baseURL    *url.URL
 	auth       authenticator
 	userAgent  string
+	decode     utils.Decoder
+	rand       *utils.LockedRand
+
+	// embeddingCache backs EmbeddingCached, and Embedding when WithEmbeddingCache is set, for every
+	// embedding client of this Client. Without WithEmbeddingCache it is allocated on first use.
+	embeddingCache     EmbeddingCache
+	embeddingCacheOnce sync.Once
+	// schemas caches collection schemas by model.CollectionLocator for WithSchemaValidation.
+	schemas sync.Map
 }
 
 func newTransport(cfg Config, authConfig Auth) (*transport, error) {
@@ -150,15 +174,43 @@ func newTransport(cfg Config, authConfig Auth) (*transport, error) {
 		cfg.MaxRetries = 0
 	}
 
+	if prefix := strings.Trim(cfg.PathPrefix, "/"); prefix != "" {
+		cfg.PathPrefix = "/" + prefix
+	} else {
+		cfg.PathPrefix = ""
+	}
+
+	decode := utils.Decoder(utils.ParseJSONUseNumber)
+	if cfg.FloatDecoding {
+		decode = utils.ParseJSON
+	}
+	if cfg.CaptureUnknownFields {
+		decode = utils.CaptureUnknownFields(decode)
+	}
+
 	return &transport{
 		config:     cfg,
 		httpClient: httpClient,
 		baseURL:    baseURL,
 		auth:       auth,
 		userAgent:  userAgent,
+		decode:     decode,
+		rand:       utils.NewLockedRand(cfg.RandSource),
+
+		embeddingCache: cfg.EmbeddingCache,
 	}, nil
 }
 
+// cache returns the configured EmbeddingCache, allocating the default LRU the first time it is needed.
+func (t *transport) cache() EmbeddingCache {
+	t.embeddingCacheOnce.Do(func() {
+		if t.embeddingCache == nil {
+			t.embeddingCache = NewLRUEmbeddingCache(defaultEmbeddingCacheSize)
+		}
+	})
+	return t.embeddingCache
+}
+
 // Client represents the entry point for interacting with VikingDB services.
 type Client struct {
 	transport *transport
@@ -179,6 +231,68 @@ func New(auth Auth, opts ...ClientOption) (*Client, error) {
 	return &Client{transport: transport}, nil
 }
 
+// credentialCheckCollection names a collection that is not expected to exist; VerifyCredentials
+// addresses it so the check never touches user data.
+const credentialCheckCollection = "__vikingdb_sdk_credential_check__"
+
+// VerifyCredentials sends one signed request that needs no provisioned collection or index and
+// reports whether the server accepted the credentials. It succeeds when the server gets past
+// authentication: it answers CollectionNotExists for the probe collection, or serves the fetch should
+// such a collection exist. A 401 or a signature or access-key code yields an error matching
+// model.ErrUnauthorized. Any other failure is returned as it is, including a 403, which a valid key
+// limited to some collections also gets, a network error or an unexpected status.
+func (c *Client) VerifyCredentials(ctx context.Context, opts ...RequestOption) error {
+	if c == nil || c.transport == nil {
+		return model.NewInvalidParameterError("client is not initialized")
+	}
+	req := struct {
+		model.CollectionLocator
+		model.FetchDataInCollectionRequest
+	}{
+		CollectionLocator:            model.NewCollectionLocator(credentialCheckCollection),
+		FetchDataInCollectionRequest: model.FetchDataInCollectionRequest{IDs: []interface{}{}},
+	}
+	err := c.transport.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/fetch_in_collection", req, &model.FetchDataInCollectionResponse{}, opts...)
+	var sdkErr *model.Error
+	if err == nil || !errors.As(err, &sdkErr) {
+		return err
+	}
+	if isCredentialError(sdkErr) {
+		return model.NewErrorWithCause(model.ErrCodeUnauthorized, "credentials rejected", err, sdkErr.StatusCode)
+	}
+	if sdkErr.Code == model.ErrCodeCollectionNotExists {
+		// The request was authenticated and then rejected for the missing collection.
+		return nil
+	}
+	return err
+}
+
+// credentialErrorCodes are the server codes for failed authentication. Authorization codes such as
+// AccessDenied are left out: they also reach valid keys scoped to other collections.
+var credentialErrorCodes = map[model.ErrorCode]bool{
+	model.ErrCodeUnauthorized: true,
+	"InvalidAccessKey":        true,
+	"InvalidAuthorization":    true,
+	"SignatureDoesNotMatch":   true,
+}
+
+// isCredentialError reports whether the server rejected the request's authentication.
+func isCredentialError(err *model.Error) bool {
+	return err.StatusCode == http.StatusUnauthorized || credentialErrorCodes[err.Code]
+}
+
+// Endpoint returns the normalized endpoint URL requests are sent to, including the WithPathPrefix
+// prefix; API paths such as /api/vikingdb/data/upsert are appended to it.
+func (c *Client) Endpoint() string {
+	if c == nil || c.transport == nil {
+		return ""
+	}
+	if c.transport.config.PathPrefix == "" {
+		return c.transport.baseURL.String()
+	}
+	return c.transport.baseURL.ResolveReference(&url.URL{Path: c.transport.config.PathPrefix}).String()
+}
+
 // Collection scopes the client to collection operations using the supplied locator metadata.
 func (c *Client) Collection(base model.CollectionLocator) CollectionClient {
 	if c == nil || c.transport == nil {
@@ -228,8 +342,12 @@ func (c *transport) doRequest(ctx context.Context, method, path string, request,
 	}
 
 	retries := requestOpts.MaxRetries
-	if retries <= 0 {
-		retries = c.config.MaxRetries
+	if !requestOpts.maxRetriesSet && retries <= 0 {
+		if fromCtx, ok := maxRetriesFromContext(ctx); ok {
+			retries = fromCtx
+		} else {
+			retries = c.config.MaxRetries
+		}
 	}
 	if retries < 0 {
 		retries = 0
@@ -243,25 +361,169 @@ func (c *transport) doRequest(ctx context.Context, method, path string, request,
 		}
 		body = serialized
 	}
+	if limit := c.config.MaxRequestBytes; limit > 0 && len(body) > limit {
+		return model.NewInvalidParameterError(fmt.Sprintf("request body is %d bytes, over the %d byte limit; split it into smaller requests, e.g. with BatchSize for writes", len(body), limit))
+	}
 
-	return utils.Retry(retries, func() error {
+	if c.config.DryRun != nil {
 		req, err := c.buildRequest(ctx, method, path, body, requestOpts)
 		if err != nil {
 			return err
 		}
+		if err := writeDryRun(c.config.DryRun, req, body); err != nil {
+			return model.NewErrorWithCause(model.ErrCodeUnknown, "failed to write dry run output", err, http.StatusInternalServerError)
+		}
+		return ErrDryRun
+	}
 
-		resp, err := utils.DoHTTPRequest(c.httpClient, req)
+	retryOpts := utils.RetryOptions{
+		MaxRetries: retries,
+		Jitter:     c.config.Jitter,
+		Rand:       c.rand,
+		OnRetry:    c.config.OnRetry,
+		MaxElapsed: c.config.MaxRetryElapsed,
+		Context:    ctx,
+	}
+	err := utils.RetryWithOptions(retryOpts, func() error {
+		req, err := c.buildRequest(ctx, method, path, body, requestOpts)
 		if err != nil {
 			return err
 		}
-		defer resp.Body.Close()
 
-		return utils.ParseResponse(resp, response)
+		resp, body, err := c.roundTrip(req, response)
+		if c.config.ResponseHook != nil {
+			c.config.ResponseHook(req, resp, body, err)
+		}
+		return err
 	}, utils.IsRetryableError)
+
+	var sdkErr *model.Error
+	if err != nil && c.config.IncludeRequestInErrors > 0 && errors.As(err, &sdkErr) {
+		sdkErr.RequestBody = snapshotBody(body, c.config.IncludeRequestInErrors)
+	}
+	return err
+}
+
+// roundTrip sends req and decodes a successful reply into response, returning the raw reply as well.
+func (c *transport) roundTrip(req *http.Request, response interface{}) (*http.Response, []byte, error) {
+	resp, err := utils.DoHTTPRequest(c.httpClient, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := utils.ReadBody(resp)
+	if err != nil {
+		return resp, nil, err
+	}
+	if err := utils.ParseBody(resp, body, response, c.decode); err != nil {
+		return resp, body, err
+	}
+	if recorder, ok := response.(interface{ SetHTTPStatus(int) }); ok {
+		recorder.SetHTTPStatus(resp.StatusCode)
+	}
+	return resp, body, nil
+}
+
+// defaultFanOutConcurrency caps the requests in flight when one call fans out to several, such as
+// chunked reranks, named-model embeddings, and multi-op aggregations.
+const defaultFanOutConcurrency = 4
+
+// fanOut calls fn for every index in [0, n) with at most limit calls running at once, defaulting to
+// defaultFanOutConcurrency, and returns once all have finished.
+func fanOut(n, limit int, fn func(idx int)) {
+	if limit <= 0 {
+		limit = defaultFanOutConcurrency
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for idx := 0; idx < n; idx++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(idx)
+		}(idx)
+	}
+	wg.Wait()
+}
+
+// quotaErrorCodes are the exact server codes for exhausted quota or an account in arrears.
+var quotaErrorCodes = map[model.ErrorCode]bool{
+	model.ErrCodeQuotaExceeded: true,
+	"InsufficientBalance":      true,
+	"AccountOverdue":           true,
+}
+
+// classifyQuotaError maps a 402 or a quota/arrears code onto ErrCodeQuotaExceeded, keeping the server
+// error as the cause. Throttling (429) is never reclassified, whatever its message says, so it stays
+// retryable. Only the embedding and rerank paths, which consume billed model quota, use it.
+func classifyQuotaError(err error) error {
+	var sdkErr *model.Error
+	if !errors.As(err, &sdkErr) || sdkErr.StatusCode == http.StatusTooManyRequests {
+		return err
+	}
+	if sdkErr.StatusCode != http.StatusPaymentRequired && !quotaErrorCodes[sdkErr.Code] {
+		return err
+	}
+	return &model.Error{
+		Code:       model.ErrCodeQuotaExceeded,
+		Message:    sdkErr.Message,
+		StatusCode: sdkErr.StatusCode,
+		RequestID:  sdkErr.RequestID,
+		Err:        sdkErr,
+	}
+}
+
+// sensitiveKeyMarkers are lower-cased fragments of JSON keys whose values snapshotBody masks.
+var sensitiveKeyMarkers = []string{"secret", "token", "password", "api_key", "apikey", "access_key", "credential"}
+
+// snapshotBody renders body for an error message: secret-looking values are masked and the result is
+// cut to maxBytes.
+func snapshotBody(body []byte, maxBytes int) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var decoded interface{}
+	if err := utils.ParseJSONUseNumber(body, &decoded); err == nil {
+		if masked, err := json.Marshal(maskSensitive(decoded)); err == nil {
+			body = masked
+		}
+	}
+	if len(body) > maxBytes {
+		return string(body[:maxBytes]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+func maskSensitive(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			lower := strings.ToLower(key)
+			masked := false
+			for _, marker := range sensitiveKeyMarkers {
+				if strings.Contains(lower, marker) {
+					v[key] = "***"
+					masked = true
+					break
+				}
+			}
+			if !masked {
+				v[key] = maskSensitive(item)
+			}
+		}
+	case []interface{}:
+		for idx, item := range v {
+			v[idx] = maskSensitive(item)
+		}
+	}
+	return value
 }
 
 func (c *transport) buildRequest(ctx context.Context, method, path string, body []byte, opts *RequestOptions) (*http.Request, error) {
-	targetURL := c.baseURL.ResolveReference(&url.URL{Path: path})
+	targetURL := c.baseURL.ResolveReference(&url.URL{Path: c.config.PathPrefix + path})
 	if len(opts.Query) > 0 {
 		query := targetURL.Query()
 		for k, v := range opts.Query {
@@ -288,6 +550,15 @@ func (c *transport) buildRequest(ctx context.Context, method, path string, body
 		req.Header.Set("User-Agent", c.userAgent)
 	}
 
+	if len(c.config.ForwardHeaders) > 0 {
+		if inbound := forwardHeadersFromContext(ctx); inbound != nil {
+			for _, name := range c.config.ForwardHeaders {
+				for _, v := range inbound.Values(name) {
+					req.Header.Add(name, v)
+				}
+			}
+		}
+	}
 	for k, v := range opts.Headers {
 		req.Header.Set(k, v)
 	}
@@ -301,3 +572,29 @@ func (c *transport) buildRequest(ctx context.Context, method, path string, body
 	}
 	return signedReq, nil
 }
+
+// writeDryRun prints the signed request with secret headers masked.
+func writeDryRun(w io.Writer, req *http.Request, body []byte) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s\n", req.Method, req.URL.String())
+
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range req.Header[k] {
+			if _, ok := redactedHeaders[k]; ok {
+				v = "[REDACTED]"
+			}
+			fmt.Fprintf(&buf, "%s: %s\n", k, v)
+		}
+	}
+	buf.WriteString("\n")
+	buf.Write(body)
+	buf.WriteString("\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,636 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+// scriptedReply is one canned response of a scriptedServer.
+type scriptedReply struct {
+	status int
+	body   string
+}
+
+// scriptedServer replies with the script in order, repeating the last reply once it runs out.
+type scriptedServer struct {
+	*httptest.Server
+	calls int32
+}
+
+func newScriptedServer(t *testing.T, script ...scriptedReply) *scriptedServer {
+	t.Helper()
+	s := &scriptedServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&s.calls, 1)) - 1
+		if call >= len(script) {
+			call = len(script) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(script[call].status)
+		_, _ = w.Write([]byte(script[call].body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *scriptedServer) Calls() int {
+	return int(atomic.LoadInt32(&s.calls))
+}
+
+// bodyRecorder keeps the path and JSON body of every request sent by a client built with its option.
+type bodyRecorder struct {
+	mu     sync.Mutex
+	paths  []string
+	bodies []string
+}
+
+// recordBodies returns a recorder and the WithResponseHook option that feeds it.
+func recordBodies(t *testing.T) (*bodyRecorder, ClientOption) {
+	r := &bodyRecorder{}
+	return r, WithResponseHook(func(req *http.Request, resp *http.Response, body []byte, err error) {
+		requestBody, readErr := req.GetBody()
+		require.NoError(t, readErr)
+		raw, readErr := io.ReadAll(requestBody)
+		require.NoError(t, readErr)
+		r.mu.Lock()
+		r.paths = append(r.paths, req.URL.Path)
+		r.bodies = append(r.bodies, string(raw))
+		r.mu.Unlock()
+	})
+}
+
+// Bodies returns the recorded bodies in send order.
+func (r *bodyRecorder) Bodies() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.bodies...)
+}
+
+// Paths returns the recorded request paths in send order.
+func (r *bodyRecorder) Paths() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.paths...)
+}
+
+// Last returns the most recently recorded body, or "" when nothing was sent.
+func (r *bodyRecorder) Last() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.bodies) == 0 {
+		return ""
+	}
+	return r.bodies[len(r.bodies)-1]
+}
+
+// newTestClient builds an API-key client for endpoint with unjittered backoff and three retries.
+func newTestClient(t *testing.T, endpoint string, opts ...ClientOption) *Client {
+	t.Helper()
+	opts = append([]ClientOption{
+		WithEndpoint(endpoint),
+		WithJitterStrategy(NoJitter),
+		WithMaxRetries(3),
+	}, opts...)
+	client, err := New(AuthAPIKey("test-key"), opts...)
+	require.NoError(t, err)
+	return client
+}
+
+func newTestIndexClient(t *testing.T, endpoint string, opts ...ClientOption) IndexClient {
+	t.Helper()
+	return newTestClient(t, endpoint, opts...).Index(model.NewIndexLocator("collection", "index"))
+}
+
+func randomSearch() model.SearchByRandomRequest {
+	limit := 1
+	return model.SearchByRandomRequest{SearchBase: model.SearchBase{Limit: &limit}}
+}
+
+const okSearchBody = `{"request_id":"req-ok","result":{"data":[{"id":1,"score":0.5}]}}`
+
+func TestRetryThrottledThenSucceeds(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusTooManyRequests, `{"code":"RequestLimitExceeded","message":"slow down","request_id":"req-429"}`},
+		scriptedReply{http.StatusOK, okSearchBody},
+	)
+	index := newTestIndexClient(t, server.URL)
+
+	start := time.Now()
+	resp, err := index.SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, 2, server.Calls())
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(100*time.Millisecond), "first retry should back off")
+	require.Equal(t, "req-ok", resp.RequestID)
+	require.Equal(t, http.StatusOK, resp.HTTPStatus)
+	require.Len(t, resp.Result.Data, 1)
+}
+
+func TestOnRetryReportsEachRetry(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"busy"}`},
+		scriptedReply{http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"busy"}`},
+		scriptedReply{http.StatusOK, okSearchBody},
+	)
+	var attempts []int
+	var delays []time.Duration
+	index := newTestIndexClient(t, server.URL, WithOnRetry(func(attempt int, err error, nextDelay time.Duration) {
+		require.True(t, errors.Is(err, model.NewServiceUnavailableError("")))
+		attempts = append(attempts, attempt)
+		delays = append(delays, nextDelay)
+	}))
+
+	_, err := index.SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, attempts)
+	require.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, delays)
+}
+
+func TestRetryExhaustedOnServiceUnavailable(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"busy","request_id":"req-503"}`},
+	)
+	index := newTestIndexClient(t, server.URL, WithMaxRetries(2))
+
+	start := time.Now()
+	_, err := index.SearchByRandom(context.Background(), randomSearch())
+	require.Error(t, err)
+	require.Equal(t, 3, server.Calls(), "one attempt plus two retries")
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(300*time.Millisecond), "backoff should double between retries")
+
+	var sdkErr *model.Error
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, model.ErrCodeServiceUnavailable, sdkErr.Code)
+	require.Equal(t, http.StatusServiceUnavailable, sdkErr.StatusCode)
+	require.Equal(t, "req-503", sdkErr.RequestID)
+}
+
+func TestMaxRetriesPrecedence(t *testing.T) {
+	cases := []struct {
+		name      string
+		ctx       func(context.Context) context.Context
+		opts      []RequestOption
+		wantCalls int
+	}{
+		{name: "client", ctx: func(ctx context.Context) context.Context { return ctx }, wantCalls: 3},
+		{name: "context over client", ctx: func(ctx context.Context) context.Context { return ContextWithMaxRetries(ctx, 1) }, wantCalls: 2},
+		{name: "context zero", ctx: func(ctx context.Context) context.Context { return ContextWithMaxRetries(ctx, 0) }, wantCalls: 1},
+		{name: "request zero over context", ctx: func(ctx context.Context) context.Context { return ContextWithMaxRetries(ctx, 1) }, opts: []RequestOption{WithRequestMaxRetries(0)}, wantCalls: 1},
+		{name: "request over context", ctx: func(ctx context.Context) context.Context { return ContextWithMaxRetries(ctx, 0) }, opts: []RequestOption{WithRequestMaxRetries(1)}, wantCalls: 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newScriptedServer(t,
+				scriptedReply{http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"busy"}`},
+			)
+			index := newTestIndexClient(t, server.URL, WithMaxRetries(2))
+
+			_, err := index.SearchByRandom(tc.ctx(context.Background()), randomSearch(), tc.opts...)
+			require.Error(t, err)
+			require.Equal(t, tc.wantCalls, server.Calls())
+		})
+	}
+}
+
+func TestMalformedJSONIsNotRetried(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, `{"result":`})
+	index := newTestIndexClient(t, server.URL)
+
+	_, err := index.SearchByRandom(context.Background(), randomSearch())
+	require.Error(t, err)
+	require.Equal(t, 1, server.Calls())
+
+	var sdkErr *model.Error
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, model.ErrCodeUnknown, sdkErr.Code)
+	require.Contains(t, sdkErr.Message, "unmarshal")
+}
+
+func TestClientErrorIsNotRetried(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusBadRequest, `{"code":"InvalidParameter","message":"bad filter","request_id":"req-400"}`},
+	)
+	index := newTestIndexClient(t, server.URL)
+
+	_, err := index.SearchByRandom(context.Background(), randomSearch())
+	require.Error(t, err)
+	require.Equal(t, 1, server.Calls())
+
+	var sdkErr *model.Error
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, model.ErrCodeInvalidParameter, sdkErr.Code)
+	require.Equal(t, "bad filter", sdkErr.Message)
+}
+
+func TestUnstructuredErrorBodyIsRetried(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusBadGateway, `<html>bad gateway</html>`},
+		scriptedReply{http.StatusOK, okSearchBody},
+	)
+	index := newTestIndexClient(t, server.URL)
+
+	resp, err := index.SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, 2, server.Calls())
+	require.Equal(t, "req-ok", resp.RequestID)
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	cases := []struct {
+		name     string
+		reply    scriptedReply
+		rejected bool
+	}{
+		{"unauthorized", scriptedReply{http.StatusUnauthorized, `{"code":"InvalidAccessKey","message":"access key not found"}`}, true},
+		{"bad signature", scriptedReply{http.StatusBadRequest, `{"code":"SignatureDoesNotMatch","message":"signature mismatch"}`}, true},
+		{"missing probe collection", scriptedReply{http.StatusNotFound, `{"code":"CollectionNotExists","message":"collection not exist"}`}, false},
+		{"probe collection served", scriptedReply{http.StatusOK, `{"result":{"fetch":[]}}`}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newScriptedServer(t, tc.reply)
+			client, err := New(AuthIAM("ak", "sk"), WithEndpoint(server.URL))
+			require.NoError(t, err)
+			err = client.VerifyCredentials(context.Background())
+			if !tc.rejected {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, errors.Is(err, model.ErrUnauthorized), "got %v", err)
+			var sdkErr *model.Error
+			require.True(t, errors.As(err, &sdkErr))
+			require.Equal(t, tc.reply.status, sdkErr.StatusCode)
+		})
+	}
+
+	server := newScriptedServer(t, scriptedReply{http.StatusBadRequest, `{"code":"InvalidParameter","message":"bad request"}`})
+	client, err := New(AuthIAM("ak", "sk"), WithEndpoint(server.URL))
+	require.NoError(t, err)
+	err = client.VerifyCredentials(context.Background())
+	require.Error(t, err, "an unrelated 4xx does not prove the credentials valid")
+	require.False(t, errors.Is(err, model.ErrUnauthorized))
+
+	server = newScriptedServer(t, scriptedReply{http.StatusForbidden, `{"code":"AccessDenied","message":"no permission on collection"}`})
+	client, err = New(AuthIAM("ak", "sk"), WithEndpoint(server.URL))
+	require.NoError(t, err)
+	err = client.VerifyCredentials(context.Background())
+	require.False(t, errors.Is(err, model.ErrUnauthorized), "a 403 from a collection-scoped key is not a credential failure")
+	var sdkErr *model.Error
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, model.ErrorCode("AccessDenied"), sdkErr.Code)
+	require.Equal(t, http.StatusForbidden, sdkErr.StatusCode)
+}
+
+func TestIncludeRequestInErrors(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusBadRequest, `{"code":"InvalidParameter","message":"bad filter"}`},
+	)
+	request := randomSearch()
+	request.Filter = model.MapStr{"op": "must", "field": "title", "conds": []interface{}{"x"}, "access_key": "AKLT-secret"}
+
+	var sdkErr *model.Error
+	_, err := newTestIndexClient(t, server.URL, WithIncludeRequestInErrors(4096)).SearchByRandom(context.Background(), request)
+	require.True(t, errors.As(err, &sdkErr))
+	require.Contains(t, sdkErr.RequestBody, `"field":"title"`)
+	require.Contains(t, sdkErr.RequestBody, `"access_key":"***"`)
+	require.NotContains(t, sdkErr.RequestBody, "AKLT-secret")
+
+	_, err = newTestIndexClient(t, server.URL, WithIncludeRequestInErrors(16)).SearchByRandom(context.Background(), request)
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, 16+len("...(truncated)"), len(sdkErr.RequestBody))
+}
+
+func TestListIndexesPagination(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, `{"request_id":"req-p1","total":3,"page":1,"page_size":2,"indexes":[{"index_name":"a"},{"index_name":"b"}]}`},
+		scriptedReply{http.StatusOK, `{"request_id":"req-p2","total":3,"page":2,"page_size":2,"indexes":[{"index_name":"c"}]}`},
+	)
+	recorder, record := recordBodies(t)
+	client, err := New(AuthAPIKey("test-key"), WithEndpoint(server.URL), record)
+	require.NoError(t, err)
+	collection := client.Collection(model.NewCollectionLocator("collection"))
+
+	var pages int
+	request := model.ListIndexesRequest{PaginationRequest: model.PaginationRequest{PageSize: 2}}
+	for {
+		resp, err := collection.ListIndexes(context.Background(), request)
+		require.NoError(t, err)
+		require.Equal(t, 3, resp.Total)
+		pages++
+		if !resp.HasMore() {
+			require.Len(t, resp.Indexes, 1)
+			break
+		}
+		request.PaginationRequest = resp.Next()
+	}
+	require.Equal(t, 2, pages)
+	require.Equal(t, 2, server.Calls())
+	require.Equal(t, []string{"/api/vikingdb/index/list", "/api/vikingdb/index/list"}, recorder.Paths())
+	require.JSONEq(t, `{"collection_name":"collection","page":2,"page_size":2}`, recorder.Last())
+}
+
+func TestResponseHookSeesEveryAttempt(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"busy"}`},
+		scriptedReply{http.StatusOK, okSearchBody},
+	)
+	var statuses []int
+	var bodies []string
+	var errs []error
+	index := newTestIndexClient(t, server.URL, WithResponseHook(func(req *http.Request, resp *http.Response, body []byte, err error) {
+		requestBody, readErr := req.GetBody()
+		require.NoError(t, readErr)
+		sent, readErr := io.ReadAll(requestBody)
+		require.NoError(t, readErr)
+		require.Contains(t, string(sent), `"limit":1`)
+		statuses = append(statuses, resp.StatusCode)
+		bodies = append(bodies, string(body))
+		errs = append(errs, err)
+	}))
+
+	_, err := index.SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, []int{http.StatusServiceUnavailable, http.StatusOK}, statuses)
+	require.Equal(t, okSearchBody, bodies[1])
+	require.Contains(t, bodies[0], "busy")
+	require.Error(t, errs[0])
+	require.NoError(t, errs[1])
+}
+
+func TestSearchByVectorF32SentAsDenseVector(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, okSearchBody})
+	recorder, record := recordBodies(t)
+	index := newTestIndexClient(t, server.URL, record)
+
+	_, err := index.SearchByVector(context.Background(), NewSearch().Limit(1).VectorF32([]float32{0.25, -1}).Build())
+	require.NoError(t, err)
+	_, err = index.SearchByVector(context.Background(), NewSearch().Limit(1).Vector([]float64{0.25, -1}).Build())
+	require.NoError(t, err)
+	sent := recorder.Bodies()
+	require.Len(t, sent, 2)
+	require.Contains(t, sent[0], `"dense_vector":[0.25,-1]`)
+	require.Equal(t, sent[1], sent[0])
+
+	both := NewSearch().Limit(1).VectorF32([]float32{1}).Build()
+	both.DenseVector = []float64{1}
+	_, err = index.SearchByVector(context.Background(), both)
+	require.Error(t, err)
+	require.Len(t, recorder.Bodies(), 2)
+}
+
+func TestMaxRetryElapsedStopsRetrying(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"busy","request_id":"req-503"}`},
+	)
+	index := newTestIndexClient(t, server.URL, WithMaxRetries(5), WithMaxRetryElapsed(250*time.Millisecond))
+
+	start := time.Now()
+	_, err := index.SearchByRandom(context.Background(), randomSearch())
+	require.Error(t, err)
+	require.Less(t, int64(time.Since(start)), int64(250*time.Millisecond))
+	require.Equal(t, 2, server.Calls(), "the 200ms second backoff would exceed the budget")
+
+	var sdkErr *model.Error
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, "req-503", sdkErr.RequestID)
+}
+
+func TestStrictFetchReportsMissingIDs(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, `{"request_id":"req-fetch","result":{"fetch":[{"id":1,"fields":{"title":"a"}}],"ids_not_exist":[2,3]}}`},
+	)
+	request := model.FetchDataInIndexRequest{IDs: []interface{}{1, 2, 3}}
+
+	resp, err := newTestIndexClient(t, server.URL).Fetch(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, resp.Result.NotFoundIDs, 2)
+
+	resp, err = newTestIndexClient(t, server.URL, WithStrictFetch()).Fetch(context.Background(), request)
+	require.True(t, errors.Is(err, model.ErrDataNotFound), "got %v", err)
+	var notFound *model.DataNotFoundError
+	require.True(t, errors.As(err, &notFound))
+	require.Len(t, notFound.IDs, 2)
+	var sdkErr *model.Error
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, http.StatusNotFound, sdkErr.StatusCode)
+	require.Len(t, resp.Result.Items, 1, "found records are still returned")
+}
+
+func TestMaxRequestBytesRejectsEarly(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, okSearchBody})
+	index := newTestIndexClient(t, server.URL, WithMaxRequestBytes(64))
+
+	request := randomSearch()
+	request.OutputFields = []string{"a_long_field_name", "another_long_field_name", "and_one_more_field"}
+	_, err := index.SearchByRandom(context.Background(), request)
+	require.True(t, errors.Is(err, model.NewInvalidParameterError("")), "got %v", err)
+	require.Equal(t, 0, server.Calls())
+
+	_, err = index.SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, 1, server.Calls())
+}
+
+func TestUpsertAndFetchVectors(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, `{"request_id":"req-upsert","result":{"records":[{"id":42,"status":"created"}]}}`},
+		scriptedReply{http.StatusOK, `{"request_id":"req-fetch","result":{"fetch":[{"id":42,"fields":{"text":"hello"},"dense_vector":[0.5,0.25]}]}}`},
+	)
+	client, err := New(AuthAPIKey("test-key"), WithEndpoint(server.URL))
+	require.NoError(t, err)
+	collection := client.Collection(model.NewCollectionLocator("collection"))
+
+	upserted, fetched, err := collection.UpsertAndFetchVectors(context.Background(), "index", model.UpsertDataRequest{
+		WriteDataBase: model.WriteDataBase{Data: []model.MapStr{{"text": "hello"}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "req-upsert", upserted.RequestID)
+	require.Len(t, fetched.Result.Items, 1)
+	require.Equal(t, []float32{0.5, 0.25}, fetched.Result.Items[0].DenseVector)
+	require.Equal(t, 2, server.Calls())
+}
+
+func TestFetchAndDeleteCanonicalizeIDs(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, `{"request_id":"req-ok","result":{}}`})
+	recorder, record := recordBodies(t)
+	client := newTestClient(t, server.URL, record)
+	collection := client.Collection(model.CollectionLocator{CollectionName: "collection"})
+	index := client.Index(model.NewIndexLocator("collection", "index"))
+	ids := []interface{}{7.0, json.Number("8"), uint32(9)}
+
+	_, err := collection.Fetch(context.Background(), model.FetchDataInCollectionRequest{IDs: ids})
+	require.NoError(t, err)
+	require.Contains(t, recorder.Last(), `"ids":[7,8,9]`)
+	_, err = index.Fetch(context.Background(), model.FetchDataInIndexRequest{IDs: ids})
+	require.NoError(t, err)
+	require.Contains(t, recorder.Last(), `"ids":[7,8,9]`)
+	_, err = collection.Delete(context.Background(), model.DeleteDataRequest{IDs: ids})
+	require.NoError(t, err)
+	require.Contains(t, recorder.Last(), `"ids":[7,8,9]`)
+
+	for _, bad := range [][]interface{}{{1.5}, {1, "a"}} {
+		_, err = collection.Delete(context.Background(), model.DeleteDataRequest{IDs: bad})
+		require.Error(t, err)
+		_, err = index.Fetch(context.Background(), model.FetchDataInIndexRequest{IDs: bad})
+		require.Error(t, err)
+	}
+	require.Equal(t, 3, server.Calls(), "rejected ids are never sent")
+}
+
+func TestPathPrefixIsPrepended(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, okSearchBody})
+	var paths []string
+	hook := WithResponseHook(func(req *http.Request, resp *http.Response, body []byte, err error) {
+		paths = append(paths, req.URL.Path)
+	})
+
+	_, err := newTestIndexClient(t, server.URL, hook, WithPathPrefix("/gateway/vikingdb/")).SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	_, err = newTestIndexClient(t, server.URL, hook).SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, []string{"/gateway/vikingdb/api/vikingdb/data/search/random", "/api/vikingdb/data/search/random"}, paths)
+
+	require.Equal(t, server.URL+"/gateway/vikingdb", newTestClient(t, server.URL, WithPathPrefix("gateway/vikingdb/")).Endpoint())
+	require.Equal(t, server.URL, newTestClient(t, server.URL).Endpoint())
+}
+
+func TestSearchByScalarSendsFilterAndOrder(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, okSearchBody})
+	recorder, record := recordBodies(t)
+	index := newTestIndexClient(t, server.URL, record)
+
+	field := "score"
+	request := model.SearchByScalarRequest{
+		SearchBase: NewSearch().Filter(model.MapStr{"op": "range", "field": "paragraph", "gte": 10}).Limit(3).Base(),
+		Field:      &field,
+		Order:      model.ScalarOrderDesc,
+	}
+	_, err := index.SearchByScalar(context.Background(), request)
+	require.NoError(t, err)
+	sent := recorder.Last()
+	require.Contains(t, sent, `"filter":{"field":"paragraph","gte":10,"op":"range"}`)
+	require.Contains(t, sent, `"field":"score"`)
+	require.Contains(t, sent, `"order":"desc"`)
+
+	request.Order = "descending"
+	_, err = index.SearchByScalar(context.Background(), request)
+	require.Error(t, err)
+	require.Equal(t, 1, server.Calls())
+}
+
+func TestAggregateConcurrentCollectsEveryOp(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, `{"request_id":"req-agg","result":{"agg":{"__TOTAL__":3}}}`})
+	index := newTestIndexClient(t, server.URL)
+
+	request := model.MultiAggRequest{Ops: []model.AggSpec{
+		{Op: model.AggOpCount},
+		{Op: "min", Field: "score"},
+		{Op: "max", Field: "score"},
+		{Op: "avg", Field: "score"},
+	}}
+	resp, err := index.AggregateConcurrent(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, 4, server.Calls(), "one request per op")
+	require.Len(t, resp.Results, 4)
+	require.Equal(t, int64(3), resp.Results["count"].Count)
+	require.Contains(t, resp.Results, "avg/score")
+	require.Equal(t, []string{"req-agg", "req-agg", "req-agg", "req-agg"}, resp.RequestIDs)
+
+	request.Ops = append(request.Ops, model.AggSpec{Op: "min", Field: "score"})
+	_, err = index.AggregateConcurrent(context.Background(), request)
+	require.Error(t, err)
+	require.Equal(t, 4, server.Calls())
+}
+
+func TestDryRunWritesMaskedRequestWithoutSending(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, okSearchBody})
+	var out bytes.Buffer
+
+	_, err := newTestIndexClient(t, server.URL, WithDryRun(&out)).SearchByRandom(context.Background(), randomSearch())
+	require.ErrorIs(t, err, ErrDryRun)
+	require.Equal(t, 0, server.Calls())
+	dump := out.String()
+	require.Contains(t, dump, "POST "+server.URL+"/api/vikingdb/data/search/random")
+	require.Contains(t, dump, "Authorization: [REDACTED]")
+	require.NotContains(t, dump, "test-key")
+	require.Contains(t, dump, `"index_name":"index"`)
+}
+
+func TestFloatDecodingDecodesNumbersAsFloat64(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, `{"request_id":"req-ok","result":{"data":[{"id":1,"score":0.5,"fields":{"price":12.5,"stock":3}}]}}`})
+
+	response, err := newTestIndexClient(t, server.URL).SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, json.Number("12.5"), response.Result.Data[0].Fields["price"])
+
+	response, err = newTestIndexClient(t, server.URL, WithFloatDecoding()).SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, 12.5, response.Result.Data[0].Fields["price"])
+	require.Equal(t, float64(3), response.Result.Data[0].Fields["stock"])
+}
+
+func TestCaptureUnknownFieldsFillsExtra(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, `{"request_id":"req-ok","trace":"abc","result":{"data":[]}}`})
+
+	response, err := newTestIndexClient(t, server.URL).SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Nil(t, response.Extra)
+
+	response, err = newTestIndexClient(t, server.URL, WithCaptureUnknownFields()).SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, "req-ok", response.RequestID)
+	require.Equal(t, model.MapStr{"trace": "abc"}, response.Extra, "modeled keys stay out of Extra")
+}
+
+func TestForwardHeadersCopiesOnlyNamedHeaders(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, okSearchBody})
+	var sent http.Header
+	hook := WithResponseHook(func(req *http.Request, resp *http.Response, body []byte, err error) {
+		sent = req.Header.Clone()
+	})
+	inbound := http.Header{}
+	inbound.Add("X-Tenant", "acme")
+	inbound.Add("X-Trace-Id", "t-1")
+	inbound.Add("X-Trace-Id", "t-2")
+	inbound.Add("Cookie", "session=secret")
+	ctx := ContextWithForwardHeaders(context.Background(), inbound)
+
+	_, err := newTestIndexClient(t, server.URL, hook, WithForwardHeaders("X-Tenant", "X-Trace-Id")).SearchByRandom(ctx, randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, []string{"acme"}, sent.Values("X-Tenant"))
+	require.Equal(t, []string{"t-1", "t-2"}, sent.Values("X-Trace-Id"))
+	require.Empty(t, sent.Values("Cookie"))
+
+	_, err = newTestIndexClient(t, server.URL, hook).SearchByRandom(ctx, randomSearch())
+	require.NoError(t, err)
+	require.Empty(t, sent.Values("X-Tenant"), "nothing is forwarded without WithForwardHeaders")
+}
+
+func TestExpiredContextIsNotRetried(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"busy"}`})
+	ctx, cancel := context.WithCancel(context.Background())
+	index := newTestIndexClient(t, server.URL, WithOnRetry(func(attempt int, err error, delay time.Duration) {
+		cancel()
+	}))
+
+	start := time.Now()
+	_, err := index.SearchByRandom(ctx, randomSearch())
+	require.Error(t, err)
+	require.Equal(t, 1, server.Calls())
+	require.Less(t, int64(time.Since(start)), int64(100*time.Millisecond), "the caller's cancellation cuts the backoff short")
+}
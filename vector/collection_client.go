@@ -4,8 +4,16 @@
 package vector
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
 	"net/http"
+	"sort"
+	"sync"
 
 	"github.com/volcengine/vikingdb-go-sdk/vector/model"
 )
@@ -15,7 +23,105 @@ type collectionClient struct {
 	collectionBase model.CollectionLocator
 }
 
+// prepareWrite prunes sparse vectors when configured and checks the fields shared by upsert and
+// update before sending, including the collection schema when schema validation is enabled.
+func (c *collectionClient) prepareWrite(ctx context.Context, base *model.WriteDataBase, opts ...RequestOption) error {
+	base.Data = model.PruneSparseFields(base.Data, c.client.config.SparsePruneTopK)
+	if err := checkWriteSettings(*base); err != nil {
+		return err
+	}
+	if err := model.ValidateSparseFields(base.Data, c.client.config.SparseMaxNonZeros); err != nil {
+		return err
+	}
+	if !c.client.config.SchemaValidation {
+		return nil
+	}
+	schema, err := c.cachedSchema(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	for idx, record := range base.Data {
+		if err := model.ValidateRecord(record, schema); err != nil {
+			return model.NewErrorWithCause(model.ErrCodeInvalidParameter, fmt.Sprintf("data[%d] does not match the collection schema", idx), err, http.StatusBadRequest)
+		}
+	}
+	return nil
+}
+
+// checkWriteSettings checks the settings of a write that do not depend on its records.
+func checkWriteSettings(base model.WriteDataBase) error {
+	if base.TTL != nil && *base.TTL < 0 {
+		return model.NewInvalidParameterError("ttl cannot be negative")
+	}
+	if base.BatchSize < 0 {
+		return model.NewInvalidParameterError("batch size cannot be negative")
+	}
+	return nil
+}
+
+// cachedSchema returns the collection's fields, reading them with Describe once per client.
+func (c *collectionClient) cachedSchema(ctx context.Context, opts ...RequestOption) ([]model.FieldSchema, error) {
+	if cached, ok := c.client.schemas.Load(c.collectionBase); ok {
+		return cached.([]model.FieldSchema), nil
+	}
+	fields, err := c.Fields(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.client.schemas.Store(c.collectionBase, fields)
+	return fields, nil
+}
+
+// chunkRecords splits records into consecutive slices of at most size records.
+func chunkRecords(records []model.MapStr, size int) [][]model.MapStr {
+	chunks := make([][]model.MapStr, 0, (len(records)+size-1)/size)
+	for start := 0; start < len(records); start += size {
+		end := start + size
+		if end > len(records) {
+			end = len(records)
+		}
+		chunks = append(chunks, records[start:end])
+	}
+	return chunks
+}
+
+// vectorizeBatchErrorCodes are the exact server codes for a multi-record write to a collection with
+// server-side vectorization.
+var vectorizeBatchErrorCodes = map[model.ErrorCode]bool{
+	"VectorizeBatchNotSupported": true,
+}
+
+// classifyWriteError points single-record vectorize rejections at WriteDataBase.BatchSize, keeping the
+// server code, status and the original error as the cause. Messages are never inspected.
+func classifyWriteError(err error) error {
+	var sdkErr *model.Error
+	if !errors.As(err, &sdkErr) || !vectorizeBatchErrorCodes[sdkErr.Code] {
+		return err
+	}
+	return &model.Error{
+		Code:       sdkErr.Code,
+		Message:    sdkErr.Message + " (vectorize collections accept one record per write; set BatchSize to 1)",
+		StatusCode: sdkErr.StatusCode,
+		RequestID:  sdkErr.RequestID,
+		Err:        sdkErr,
+	}
+}
+
 func (c *collectionClient) Upsert(ctx context.Context, request model.UpsertDataRequest, opts ...RequestOption) (*model.UpsertDataResponse, error) {
+	if err := c.collectionBase.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.prepareWrite(ctx, &request.WriteDataBase, opts...); err != nil {
+		return nil, err
+	}
+	return c.upsert(ctx, request, opts...)
+}
+
+// upsert sends a request already checked by prepareWrite, in BatchSize chunks when set.
+func (c *collectionClient) upsert(ctx context.Context, request model.UpsertDataRequest, opts ...RequestOption) (*model.UpsertDataResponse, error) {
+	if request.BatchSize > 0 && len(request.Data) > request.BatchSize {
+		return c.upsertChunked(ctx, request, opts...)
+	}
 	response := &model.UpsertDataResponse{}
 	req := struct {
 		model.CollectionLocator
@@ -25,10 +131,243 @@ func (c *collectionClient) Upsert(ctx context.Context, request model.UpsertDataR
 		UpsertDataRequest: request,
 	}
 	err := c.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/upsert", req, response, opts...)
-	return response, err
+	if err == nil && (request.Async || response.HTTPStatus == http.StatusAccepted) {
+		if response.Result == nil {
+			response.Result = &model.UpsertDataResult{}
+		}
+		response.Result.Accepted = true
+	}
+	return response, classifyWriteError(err)
+}
+
+// upsertChunked sends request.Data in BatchSize chunks. The merged result collects record statuses and
+// the token usage of every chunk; on failure it covers the chunks written before the error.
+func (c *collectionClient) upsertChunked(ctx context.Context, request model.UpsertDataRequest, opts ...RequestOption) (*model.UpsertDataResponse, error) {
+	merged := &model.UpsertDataResponse{Result: &model.UpsertDataResult{}}
+	usages := make([]interface{}, 0)
+	for _, chunk := range chunkRecords(request.Data, request.BatchSize) {
+		single := request
+		single.Data = chunk
+		single.BatchSize = 0
+		resp, err := c.upsert(ctx, single, opts...)
+		if err != nil {
+			merged.Result.TokenUsage = usages
+			return merged, err
+		}
+		merged.CommonResponse = resp.CommonResponse
+		if resp.Result != nil {
+			merged.Result.Accepted = merged.Result.Accepted || resp.Result.Accepted
+			merged.Result.Records = append(merged.Result.Records, resp.Result.Records...)
+			usages = append(usages, resp.Result.TokenUsage)
+		}
+	}
+	merged.Result.TokenUsage = usages
+	return merged, nil
+}
+
+// defaultUpsertConcurrency is the number of chunks UpsertBatchConcurrent keeps in flight by default.
+const defaultUpsertConcurrency = 4
+
+// UpsertBatchConcurrent upserts data in chunks across several goroutines. When ctx is cancelled (or a
+// chunk fails with StopOnError set) no new chunks are launched, in-flight chunks are awaited, and the
+// result lists exactly which ranges committed. The returned error is ctx.Err() after cancellation,
+// otherwise the first chunk failure.
+func (c *collectionClient) UpsertBatchConcurrent(ctx context.Context, data []model.MapStr, options model.ConcurrentUpsertOptions, opts ...RequestOption) (*model.ConcurrentUpsertResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultUpsertConcurrency
+	}
+
+	ranges := make([]model.ChunkRange, 0, (len(data)+batchSize-1)/batchSize)
+	for start := 0; start < len(data); start += batchSize {
+		end := start + batchSize
+		if end > len(data) {
+			end = len(data)
+		}
+		ranges = append(ranges, model.ChunkRange{Start: start, End: end})
+	}
+
+	result := &model.ConcurrentUpsertResult{}
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		stopped  bool
+	)
+	sem := make(chan struct{}, concurrency)
+
+	for idx, r := range ranges {
+		acquired := false
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+				acquired = true
+			case <-ctx.Done():
+			}
+		}
+		mu.Lock()
+		halt := stopped
+		mu.Unlock()
+		if halt || ctx.Err() != nil {
+			if acquired {
+				<-sem
+			}
+			result.Skipped = append(result.Skipped, ranges[idx:]...)
+			break
+		}
+
+		wg.Add(1)
+		go func(r model.ChunkRange) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			req := model.UpsertDataRequest{
+				WriteDataBase: model.WriteDataBase{
+					Data:                data[r.Start:r.End],
+					TTL:                 options.TTL,
+					IgnoreUnknownFields: options.IgnoreUnknownFields,
+				},
+			}
+			_, err := c.Upsert(ctx, req, opts...)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failed = append(result.Failed, model.ChunkFailure{ChunkRange: r, Err: err})
+				if firstErr == nil {
+					firstErr = err
+				}
+				if options.StopOnError {
+					stopped = true
+				}
+				return
+			}
+			result.Committed = append(result.Committed, r)
+		}(r)
+	}
+	wg.Wait()
+
+	sort.Slice(result.Committed, func(i, j int) bool { return result.Committed[i].Start < result.Committed[j].Start })
+	sort.Slice(result.Failed, func(i, j int) bool { return result.Failed[i].Start < result.Failed[j].Start })
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	return result, firstErr
+}
+
+// UpsertWithEmbedding embeds TextField of every record in one embedding call, injects the vectors, and
+// upserts the records in chunks of BatchSize.
+func (c *collectionClient) UpsertWithEmbedding(ctx context.Context, request model.EmbedAndUpsertRequest, opts ...RequestOption) (*model.EmbedAndUpsertResponse, error) {
+	if request.TextField == "" || request.VectorField == "" {
+		return nil, model.NewInvalidParameterError("text field and vector field cannot be empty")
+	}
+	if request.DenseModel == nil {
+		return nil, model.NewInvalidParameterError("dense model cannot be empty")
+	}
+	if request.SparseModel != nil && request.SparseVectorField == "" {
+		return nil, model.NewInvalidParameterError("sparse vector field cannot be empty when sparse model is set")
+	}
+	if len(request.Data) == 0 {
+		return nil, model.NewInvalidParameterError("data cannot be empty")
+	}
+	if err := c.collectionBase.Validate(); err != nil {
+		return nil, err
+	}
+	// The records are checked once, after the vectors are injected; only their settings can be
+	// checked before spending tokens on the embedding call.
+	if err := checkWriteSettings(request.WriteDataBase); err != nil {
+		return nil, err
+	}
+
+	embedReq := model.EmbeddingRequest{
+		DenseModel:  request.DenseModel,
+		SparseModel: request.SparseModel,
+		Data:        make([]*model.EmbeddingData, 0, len(request.Data)),
+	}
+	if c.collectionBase.ProjectName != "" {
+		projectName := c.collectionBase.ProjectName
+		embedReq.ProjectName = &projectName
+	}
+	for idx, record := range request.Data {
+		text, ok := record[request.TextField].(string)
+		if !ok {
+			return nil, model.NewInvalidParameterError(fmt.Sprintf("data[%d].%s must be a string", idx, request.TextField))
+		}
+		embedReq.Data = append(embedReq.Data, &model.EmbeddingData{Text: &text})
+	}
+
+	embedder := &embeddingClient{client: c.client}
+	embedResp, err := embedder.Embedding(ctx, embedReq, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if embedResp.Result == nil || len(embedResp.Result.Data) != len(request.Data) {
+		return nil, model.NewError(model.ErrCodeEmbeddingFailed, "embedding returned an unexpected number of vectors")
+	}
+
+	records := make([]model.MapStr, 0, len(request.Data))
+	for idx, record := range request.Data {
+		enriched := make(model.MapStr, len(record)+2)
+		for k, v := range record {
+			enriched[k] = v
+		}
+		embedding := embedResp.Result.Data[idx]
+		enriched[request.VectorField] = embedding.DenseVectors
+		if request.SparseModel != nil {
+			enriched[request.SparseVectorField] = embedding.SparseVectors
+		}
+		records = append(records, enriched)
+	}
+
+	batchSize := request.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	write := request.WriteDataBase
+	write.Data = records
+	if err := c.prepareWrite(ctx, &write, opts...); err != nil {
+		return nil, err
+	}
+
+	response := &model.EmbedAndUpsertResponse{Embedding: embedResp}
+	for _, chunk := range chunkRecords(write.Data, batchSize) {
+		upsertReq := model.UpsertDataRequest{
+			WriteDataBase: write,
+			Async:         request.Async,
+		}
+		upsertReq.Data = chunk
+		upsertReq.BatchSize = 0
+		upsertResp, err := c.upsert(ctx, upsertReq, opts...)
+		response.Upserts = append(response.Upserts, upsertResp)
+		if err != nil {
+			return response, err
+		}
+	}
+	return response, nil
 }
 
 func (c *collectionClient) Update(ctx context.Context, request model.UpdateDataRequest, opts ...RequestOption) (*model.UpdateDataResponse, error) {
+	if err := c.collectionBase.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.prepareWrite(ctx, &request.WriteDataBase, opts...); err != nil {
+		return nil, err
+	}
+	return c.update(ctx, request, opts...)
+}
+
+// update sends a request already checked by prepareWrite, in BatchSize chunks when set.
+func (c *collectionClient) update(ctx context.Context, request model.UpdateDataRequest, opts ...RequestOption) (*model.UpdateDataResponse, error) {
+	if request.BatchSize > 0 && len(request.Data) > request.BatchSize {
+		return c.updateChunked(ctx, request, opts...)
+	}
 	response := &model.UpdateDataResponse{}
 	req := struct {
 		model.CollectionLocator
@@ -38,10 +377,40 @@ func (c *collectionClient) Update(ctx context.Context, request model.UpdateDataR
 		UpdateDataRequest: request,
 	}
 	err := c.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/update", req, response, opts...)
-	return response, err
+	return response, classifyWriteError(err)
+}
+
+// updateChunked sends request.Data in BatchSize chunks, collecting the token usage of every chunk.
+func (c *collectionClient) updateChunked(ctx context.Context, request model.UpdateDataRequest, opts ...RequestOption) (*model.UpdateDataResponse, error) {
+	merged := &model.UpdateDataResponse{Result: &model.UpdateDataResult{}}
+	usages := make([]interface{}, 0)
+	for _, chunk := range chunkRecords(request.Data, request.BatchSize) {
+		single := request
+		single.Data = chunk
+		single.BatchSize = 0
+		resp, err := c.update(ctx, single, opts...)
+		if err != nil {
+			merged.Result.TokenUsage = usages
+			return merged, err
+		}
+		merged.CommonResponse = resp.CommonResponse
+		if resp.Result != nil {
+			usages = append(usages, resp.Result.TokenUsage)
+		}
+	}
+	merged.Result.TokenUsage = usages
+	return merged, nil
 }
 
 func (c *collectionClient) Delete(ctx context.Context, request model.DeleteDataRequest, opts ...RequestOption) (*model.DeleteDataResponse, error) {
+	if err := c.collectionBase.Validate(); err != nil {
+		return nil, err
+	}
+	ids, err := model.CanonicalIDs(request.IDs)
+	if err != nil {
+		return nil, err
+	}
+	request.IDs = ids
 	response := &model.DeleteDataResponse{}
 	req := struct {
 		model.CollectionLocator
@@ -50,11 +419,19 @@ func (c *collectionClient) Delete(ctx context.Context, request model.DeleteDataR
 		CollectionLocator: c.collectionBase,
 		DeleteDataRequest: request,
 	}
-	err := c.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/delete", req, response, opts...)
+	err = c.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/delete", req, response, opts...)
 	return response, err
 }
 
 func (c *collectionClient) Fetch(ctx context.Context, request model.FetchDataInCollectionRequest, opts ...RequestOption) (*model.FetchDataInCollectionResponse, error) {
+	if err := c.collectionBase.Validate(); err != nil {
+		return nil, err
+	}
+	ids, err := model.CanonicalIDs(request.IDs)
+	if err != nil {
+		return nil, err
+	}
+	request.IDs = ids
 	response := &model.FetchDataInCollectionResponse{}
 	req := struct {
 		model.CollectionLocator
@@ -63,10 +440,309 @@ func (c *collectionClient) Fetch(ctx context.Context, request model.FetchDataInC
 		CollectionLocator:            c.collectionBase,
 		FetchDataInCollectionRequest: request,
 	}
-	err := c.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/fetch_in_collection", req, response, opts...)
+	err = c.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/fetch_in_collection", req, response, opts...)
+	if err == nil && c.client.config.StrictFetch && response.Result != nil && len(response.Result.NotFoundIDs) > 0 {
+		err = model.NewDataNotFoundError(response.Result.NotFoundIDs)
+	}
+	return response, err
+}
+
+// Describe reads the collection's metadata. It posts the collection locator, {"collection_name"} or
+// {"resource_id"}, to /api/vikingdb/collection/info and decodes "result" into CollectionInfo: the name,
+// primary_key, and fields with field_name, field_type, dim and is_primary_key. The endpoint is not one
+// of the documented data APIs the other methods use; deployments that do not serve it answer 404, and
+// that *model.Error is returned unchanged, so Fields, PrimaryKey and WithSchemaValidation fail the
+// same way there.
+func (c *collectionClient) Describe(ctx context.Context, opts ...RequestOption) (*model.DescribeCollectionResponse, error) {
+	if err := c.collectionBase.Validate(); err != nil {
+		return nil, err
+	}
+	response := &model.DescribeCollectionResponse{}
+	err := c.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/collection/info", c.collectionBase, response, opts...)
+	return response, err
+}
+
+// Fields returns the collection schema, marking the primary key field even when the server only
+// reports it through CollectionInfo.PrimaryKey.
+func (c *collectionClient) Fields(ctx context.Context, opts ...RequestOption) ([]model.FieldSchema, error) {
+	resp, err := c.Describe(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Result == nil {
+		return nil, model.NewError(model.ErrCodeUnknown, "describe returned no collection info")
+	}
+	fields := make([]model.FieldSchema, len(resp.Result.Fields))
+	copy(fields, resp.Result.Fields)
+	for idx := range fields {
+		if fields[idx].Name == resp.Result.PrimaryKey {
+			fields[idx].IsPrimary = true
+		}
+	}
+	return fields, nil
+}
+
+// UpsertAndFetchVectors upserts request and then fetches the written documents through indexName,
+// returning the vectors the server stored for them, such as those a vectorize collection generates.
+// Document ids come from the upsert's record statuses, which include auto-generated ids, or else from
+// the primary key of each record. Queued writes (Async or HTTP 202) are not yet visible, so they fail
+// with an InvalidParameter error after the upsert response.
+func (c *collectionClient) UpsertAndFetchVectors(ctx context.Context, indexName string, request model.UpsertDataRequest, opts ...RequestOption) (*model.UpsertDataResponse, *model.FetchDataInIndexResponse, error) {
+	if indexName == "" {
+		return nil, nil, model.NewInvalidParameterError("index_name cannot be empty")
+	}
+	upserted, err := c.Upsert(ctx, request, opts...)
+	if err != nil {
+		return upserted, nil, err
+	}
+	if upserted.Result != nil && upserted.Result.Accepted {
+		return upserted, nil, model.NewInvalidParameterError("upsert was queued; its vectors cannot be fetched yet")
+	}
+
+	var ids []interface{}
+	if upserted.Result != nil && len(upserted.Result.Records) == len(request.Data) {
+		for _, record := range upserted.Result.Records {
+			ids = append(ids, record.ID)
+		}
+	} else {
+		primaryKey, err := c.PrimaryKey(ctx, opts...)
+		if err != nil {
+			return upserted, nil, err
+		}
+		for idx, record := range request.Data {
+			id, ok := record[primaryKey]
+			if !ok {
+				return upserted, nil, model.NewInvalidParameterError(fmt.Sprintf("data[%d] has no %s and the server did not report its id", idx, primaryKey))
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	index := &indexClient{
+		transport: c.client,
+		indexBase: model.IndexLocator{CollectionLocator: c.collectionBase, IndexName: indexName},
+	}
+	fetched, err := index.fetch(ctx, model.FetchDataInIndexRequest{IDs: ids}, opts...)
+	return upserted, fetched, err
+}
+
+// UpdateChanged fetches the document with the given primary key and updates only the fields of
+// newFields whose values differ from the stored ones. Values are compared as JSON with numbers
+// compared by value, so 3 and 3.0 are equal. When nothing changed no update is sent and the returned response is nil.
+func (c *collectionClient) UpdateChanged(ctx context.Context, id interface{}, newFields model.MapStr, opts ...RequestOption) (*model.UpdateDataResponse, error) {
+	primaryKey, err := c.PrimaryKey(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	fetched, err := c.Fetch(ctx, model.FetchDataInCollectionRequest{IDs: []interface{}{id}}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	current, ok := fetched.Result.Item(id)
+	if !ok {
+		return nil, model.NewErrorWithStatusCode(model.ErrCodeDataNotFound, fmt.Sprintf("document %v not found", id), http.StatusNotFound)
+	}
+
+	delta := model.MapStr{}
+	for name, value := range newFields {
+		if name == primaryKey {
+			continue
+		}
+		if old, exists := current.Fields[name]; exists && sameJSON(old, value) {
+			continue
+		}
+		delta[name] = value
+	}
+	if len(delta) == 0 {
+		return nil, nil
+	}
+	delta[primaryKey] = current.ID
+
+	return c.Update(ctx, model.UpdateDataRequest{
+		WriteDataBase: model.WriteDataBase{Data: []model.MapStr{delta}},
+	}, opts...)
+}
+
+// sameJSON reports whether a and b encode to equal JSON values, comparing numbers by value.
+func sameJSON(a, b interface{}) bool {
+	left, err := jsonValue(a)
+	if err != nil {
+		return false
+	}
+	right, err := jsonValue(b)
+	if err != nil {
+		return false
+	}
+	return equalJSONValues(left, right)
+}
+
+// jsonValue round-trips v through JSON, keeping numbers as json.Number.
+func jsonValue(v interface{}) (interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var out interface{}
+	err = decoder.Decode(&out)
+	return out, err
+}
+
+// equalJSONValues compares decoded JSON values, treating numbers with the same value as equal.
+func equalJSONValues(a, b interface{}) bool {
+	switch left := a.(type) {
+	case json.Number:
+		right, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, okX := new(big.Rat).SetString(left.String())
+		y, okY := new(big.Rat).SetString(right.String())
+		return okX && okY && x.Cmp(y) == 0
+	case map[string]interface{}:
+		right, ok := b.(map[string]interface{})
+		if !ok || len(left) != len(right) {
+			return false
+		}
+		for key, value := range left {
+			other, ok := right[key]
+			if !ok || !equalJSONValues(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		right, ok := b.([]interface{})
+		if !ok || len(left) != len(right) {
+			return false
+		}
+		for idx := range left {
+			if !equalJSONValues(left[idx], right[idx]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// ListIndexes returns one page of the collection's indexes. Request.CollectionName defaults to the
+// client's collection. It posts the request, collection_name plus optional page, page_size and
+// name_prefix, to /api/vikingdb/index/list and decodes total, page, page_size and indexes from the
+// top level of the reply. Like Describe, the endpoint is not one of the documented data APIs;
+// deployments that do not serve it answer 404, returned unchanged as a *model.Error. Iterate with
+// PaginationResponse.HasMore and Next:
+//
+//	req := model.ListIndexesRequest{}
+//	for {
+//		resp, err := collection.ListIndexes(ctx, req)
+//		...
+//		if !resp.HasMore() {
+//			break
+//		}
+//		req.PaginationRequest = resp.Next()
+//	}
+func (c *collectionClient) ListIndexes(ctx context.Context, request model.ListIndexesRequest, opts ...RequestOption) (*model.ListIndexesResponse, error) {
+	if err := c.collectionBase.Validate(); err != nil {
+		return nil, err
+	}
+	if request.Page < 0 || request.PageSize < 0 {
+		return nil, model.NewInvalidParameterError("page and page_size cannot be negative")
+	}
+	if request.CollectionName == nil {
+		if c.collectionBase.CollectionName == "" {
+			return nil, model.NewInvalidParameterError("list indexes needs a collection name")
+		}
+		name := c.collectionBase.CollectionName
+		request.CollectionName = &name
+	}
+	response := &model.ListIndexesResponse{}
+	err := c.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/index/list", request, response, opts...)
 	return response, err
 }
 
+// PrimaryKey returns the name of the collection's primary key field, e.g. __AUTO_ID__ for auto-id
+// collections.
+func (c *collectionClient) PrimaryKey(ctx context.Context, opts ...RequestOption) (string, error) {
+	resp, err := c.Describe(ctx, opts...)
+	if err != nil {
+		return "", err
+	}
+	if resp.Result == nil {
+		return "", model.NewError(model.ErrCodeUnknown, "describe returned no collection info")
+	}
+	if resp.Result.PrimaryKey != "" {
+		return resp.Result.PrimaryKey, nil
+	}
+	for _, field := range resp.Result.Fields {
+		if field.IsPrimary {
+			return field.Name, nil
+		}
+	}
+	return "", model.NewError(model.ErrCodeUnknown, "describe reported no primary key")
+}
+
+// Import reads NDJSON lines produced by IndexClient.Export and upserts them in batches.
+func (c *collectionClient) Import(ctx context.Context, r io.Reader, options model.ImportOptions, opts ...RequestOption) (*model.ImportResult, error) {
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+
+	result := &model.ImportResult{}
+	batch := make([]model.MapStr, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		req := model.UpsertDataRequest{WriteDataBase: model.WriteDataBase{Data: batch}}
+		if _, err := c.Upsert(ctx, req, opts...); err != nil {
+			return err
+		}
+		result.Imported += len(batch)
+		batch = make([]model.MapStr, 0, batchSize)
+		return nil
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	for line := 1; ; line++ {
+		var item model.IndexDataItem
+		if err := decoder.Decode(&item); err == io.EOF {
+			break
+		} else if err != nil {
+			return result, model.NewErrorWithCause(model.ErrCodeInvalidParameter, fmt.Sprintf("invalid import record %d", line), err, http.StatusBadRequest)
+		}
+
+		record := make(model.MapStr, len(item.Fields)+len(item.DenseVectors)+2)
+		for k, v := range item.Fields {
+			record[k] = v
+		}
+		for field, vec := range item.DenseVectors {
+			record[field] = vec
+		}
+		if options.PrimaryKey != "" && item.ID != nil {
+			record[options.PrimaryKey] = item.ID
+		}
+		if options.VectorField != "" && len(item.DenseVector) > 0 {
+			record[options.VectorField] = item.DenseVector
+		}
+		batch = append(batch, record)
+
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
 func (c *collectionClient) CollectionName() string {
 	return c.collectionBase.CollectionName
 }
@@ -0,0 +1,341 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+// routedServer answers each request with the handler registered for its path and counts the calls
+// per path.
+type routedServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newRoutedServer(t *testing.T, routes map[string]func(body model.MapStr) scriptedReply) *routedServer {
+	t.Helper()
+	s := &routedServer{calls: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body model.MapStr
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		s.mu.Lock()
+		s.calls[r.URL.Path]++
+		s.mu.Unlock()
+		route, ok := routes[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		reply := route(body)
+		w.WriteHeader(reply.status)
+		_, _ = w.Write([]byte(reply.body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *routedServer) Calls(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[path]
+}
+
+func numberedRecords(n int) []model.MapStr {
+	records := make([]model.MapStr, n)
+	for i := range records {
+		records[i] = model.MapStr{"id": i, "text": fmt.Sprintf("record %d", i)}
+	}
+	return records
+}
+
+// firstRecordID returns the id of the first record of an upsert or update body.
+func firstRecordID(body model.MapStr) int {
+	return int(body["data"].([]interface{})[0].(map[string]interface{})["id"].(float64))
+}
+
+const (
+	upsertPath = "/api/vikingdb/data/upsert"
+	okWrite    = `{"request_id":"req-ok","result":{}}`
+)
+
+func testCollection(t *testing.T, endpoint string, opts ...ClientOption) CollectionClient {
+	return newTestClient(t, endpoint, opts...).Collection(model.CollectionLocator{CollectionName: "collection"})
+}
+
+func TestUpsertBatchConcurrentOrdersRanges(t *testing.T) {
+	server := newRoutedServer(t, map[string]func(model.MapStr) scriptedReply{
+		upsertPath: func(body model.MapStr) scriptedReply {
+			// Later chunks answer first, so completion order is the reverse of send order.
+			time.Sleep(time.Duration(10-firstRecordID(body)) * 5 * time.Millisecond)
+			return scriptedReply{http.StatusOK, okWrite}
+		},
+	})
+
+	result, err := testCollection(t, server.URL).UpsertBatchConcurrent(context.Background(), numberedRecords(10),
+		model.ConcurrentUpsertOptions{BatchSize: 2, Concurrency: 5})
+	require.NoError(t, err)
+	require.Equal(t, []model.ChunkRange{{Start: 0, End: 2}, {Start: 2, End: 4}, {Start: 4, End: 6}, {Start: 6, End: 8}, {Start: 8, End: 10}}, result.Committed)
+	require.Empty(t, result.Failed)
+	require.Empty(t, result.Skipped)
+	require.Equal(t, 5, server.Calls(upsertPath))
+}
+
+func TestUpsertBatchConcurrentPartialFailure(t *testing.T) {
+	route := func(body model.MapStr) scriptedReply {
+		if firstRecordID(body) == 4 {
+			return scriptedReply{http.StatusBadRequest, `{"code":"InvalidParameter","message":"bad record"}`}
+		}
+		return scriptedReply{http.StatusOK, okWrite}
+	}
+
+	t.Run("continue", func(t *testing.T) {
+		server := newRoutedServer(t, map[string]func(model.MapStr) scriptedReply{upsertPath: route})
+		result, err := testCollection(t, server.URL).UpsertBatchConcurrent(context.Background(), numberedRecords(10),
+			model.ConcurrentUpsertOptions{BatchSize: 2, Concurrency: 3})
+
+		var sdkErr *model.Error
+		require.True(t, errors.As(err, &sdkErr))
+		require.Equal(t, model.ErrCodeInvalidParameter, sdkErr.Code)
+		require.Equal(t, []model.ChunkRange{{Start: 0, End: 2}, {Start: 2, End: 4}, {Start: 6, End: 8}, {Start: 8, End: 10}}, result.Committed)
+		require.Len(t, result.Failed, 1)
+		require.Equal(t, model.ChunkRange{Start: 4, End: 6}, result.Failed[0].ChunkRange)
+		require.Equal(t, err, result.Failed[0].Err)
+		require.Empty(t, result.Skipped)
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		server := newRoutedServer(t, map[string]func(model.MapStr) scriptedReply{upsertPath: route})
+		result, err := testCollection(t, server.URL).UpsertBatchConcurrent(context.Background(), numberedRecords(10),
+			model.ConcurrentUpsertOptions{BatchSize: 2, Concurrency: 1, StopOnError: true})
+		require.Error(t, err)
+		require.Equal(t, []model.ChunkRange{{Start: 0, End: 2}, {Start: 2, End: 4}}, result.Committed)
+		require.Len(t, result.Failed, 1)
+		require.Equal(t, []model.ChunkRange{{Start: 6, End: 8}, {Start: 8, End: 10}}, result.Skipped)
+		require.Equal(t, 3, server.Calls(upsertPath))
+	})
+}
+
+func TestUpsertBatchConcurrentCancellation(t *testing.T) {
+	server := newRoutedServer(t, map[string]func(model.MapStr) scriptedReply{
+		upsertPath: func(model.MapStr) scriptedReply { return scriptedReply{http.StatusOK, okWrite} },
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Cancel as soon as the first chunk has its response.
+	collection := testCollection(t, server.URL, WithResponseHook(func(*http.Request, *http.Response, []byte, error) { cancel() }))
+
+	result, err := collection.UpsertBatchConcurrent(ctx, numberedRecords(6), model.ConcurrentUpsertOptions{BatchSize: 2, Concurrency: 1})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, []model.ChunkRange{{Start: 0, End: 2}}, result.Committed)
+	require.Empty(t, result.Failed)
+	require.Equal(t, []model.ChunkRange{{Start: 2, End: 4}, {Start: 4, End: 6}}, result.Skipped)
+	require.Equal(t, 1, server.Calls(upsertPath))
+}
+
+func TestUpsertSplitsByBatchSize(t *testing.T) {
+	server := newRoutedServer(t, map[string]func(model.MapStr) scriptedReply{
+		upsertPath: func(body model.MapStr) scriptedReply {
+			data := body["data"].([]interface{})
+			if firstRecordID(body) == 4 {
+				return scriptedReply{http.StatusBadRequest, `{"code":"InvalidParameter","message":"bad record"}`}
+			}
+			records := make([]string, len(data))
+			for i, record := range data {
+				records[i] = fmt.Sprintf(`{"id":%v,"status":"created"}`, record.(map[string]interface{})["id"])
+			}
+			return scriptedReply{http.StatusOK, fmt.Sprintf(`{"request_id":"req-%d","result":{"records":[%s],"token_usage":{"m":{"total_tokens":%d}}}}`,
+				firstRecordID(body), strings.Join(records, ","), len(data))}
+		},
+	})
+	collection := testCollection(t, server.URL)
+
+	resp, err := collection.Upsert(context.Background(), model.UpsertDataRequest{WriteDataBase: model.WriteDataBase{Data: numberedRecords(4), BatchSize: 3}})
+	require.NoError(t, err)
+	require.Equal(t, 2, server.Calls(upsertPath))
+	require.Len(t, resp.Result.Records, 4)
+	require.Equal(t, "req-3", resp.RequestID, "the last chunk's envelope is kept")
+	require.Len(t, resp.Result.TokenUsage, 2, "one usage entry per chunk")
+
+	resp, err = collection.Upsert(context.Background(), model.UpsertDataRequest{WriteDataBase: model.WriteDataBase{Data: numberedRecords(8), BatchSize: 2}})
+	require.Error(t, err)
+	require.Equal(t, 5, server.Calls(upsertPath), "records 6 and 7 are not sent after the failure")
+	require.Len(t, resp.Result.Records, 4, "records of the chunks written before the failure")
+}
+
+func TestUpdateSplitsByBatchSize(t *testing.T) {
+	const updatePath = "/api/vikingdb/data/update"
+	var firstIDs []int
+	server := newRoutedServer(t, map[string]func(model.MapStr) scriptedReply{
+		updatePath: func(body model.MapStr) scriptedReply {
+			firstIDs = append(firstIDs, firstRecordID(body))
+			return scriptedReply{http.StatusOK, `{"request_id":"req-ok","result":{"token_usage":{"m":{"total_tokens":1}}}}`}
+		},
+	})
+
+	resp, err := testCollection(t, server.URL).Update(context.Background(), model.UpdateDataRequest{WriteDataBase: model.WriteDataBase{Data: numberedRecords(5), BatchSize: 2}})
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 2, 4}, firstIDs)
+	require.Len(t, resp.Result.TokenUsage, 3, "one usage entry per chunk")
+}
+
+func TestVectorizeBatchRejectionKeepsServerCode(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusBadRequest, `{"code":"VectorizeBatchNotSupported","message":"vectorize accepts one record","request_id":"req-v"}`},
+		scriptedReply{http.StatusBadRequest, `{"code":"InvalidParameter","message":"data has more than 100 records","request_id":"req-n"}`},
+	)
+	collection := testCollection(t, server.URL)
+	request := model.UpsertDataRequest{WriteDataBase: model.WriteDataBase{Data: numberedRecords(2)}}
+
+	_, err := collection.Upsert(context.Background(), request)
+	var sdkErr *model.Error
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, model.ErrorCode("VectorizeBatchNotSupported"), sdkErr.Code)
+	require.Equal(t, http.StatusBadRequest, sdkErr.StatusCode)
+	require.Equal(t, "req-v", sdkErr.RequestID)
+	require.Contains(t, sdkErr.Message, "set BatchSize to 1")
+
+	_, err = collection.Upsert(context.Background(), request)
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, model.ErrCodeInvalidParameter, sdkErr.Code)
+	require.Equal(t, "data has more than 100 records", sdkErr.Message, "other rejections are left untouched")
+}
+
+const describeBody = `{"result":{"collection_name":"collection","primary_key":"id","fields":[` +
+	`{"field_name":"id","field_type":"int64"},{"field_name":"text","field_type":"string"},` +
+	`{"field_name":"vec","field_type":"vector","dim":2},{"field_name":"tag","field_type":"string"}]}}`
+
+func TestDescribeRequestAndFields(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, describeBody},
+		scriptedReply{http.StatusNotFound, `{"code":"NotFound","message":"no such api","request_id":"req-404"}`},
+	)
+	recorder, record := recordBodies(t)
+	collection := testCollection(t, server.URL, record)
+
+	fields, err := collection.Fields(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"/api/vikingdb/collection/info"}, recorder.Paths())
+	require.JSONEq(t, `{"collection_name":"collection"}`, recorder.Last())
+	require.Equal(t, model.FieldSchema{Name: "id", Type: model.FieldTypeInt64, IsPrimary: true}, fields[0])
+	require.Equal(t, model.FieldSchema{Name: "vec", Type: model.FieldTypeVector, Dim: 2}, fields[2])
+
+	_, err = collection.PrimaryKey(context.Background())
+	var sdkErr *model.Error
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, http.StatusNotFound, sdkErr.StatusCode)
+	require.Equal(t, "req-404", sdkErr.RequestID)
+}
+
+func TestUpsertWithEmbeddingValidatesInjectedRecordsOnce(t *testing.T) {
+	var dense string
+	server := newRoutedServer(t, map[string]func(model.MapStr) scriptedReply{
+		"/api/vikingdb/collection/info": func(model.MapStr) scriptedReply { return scriptedReply{http.StatusOK, describeBody} },
+		"/api/vikingdb/embedding": func(model.MapStr) scriptedReply {
+			return scriptedReply{http.StatusOK, `{"result":{"data":[{"dense":` + dense + `},{"dense":` + dense + `}]}}`}
+		},
+		upsertPath: func(model.MapStr) scriptedReply { return scriptedReply{http.StatusOK, okWrite} },
+	})
+	recorder, record := recordBodies(t)
+	collection := testCollection(t, server.URL, WithSchemaValidation(true), record)
+	name := "bge-m3"
+	request := model.EmbedAndUpsertRequest{
+		WriteDataBase: model.WriteDataBase{Data: numberedRecords(2)},
+		TextField:     "text",
+		VectorField:   "vec",
+		DenseModel:    &model.EmbeddingModelOpt{ModelName: &name},
+	}
+
+	dense = `[0.6,0.8]`
+	resp, err := collection.UpsertWithEmbedding(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, resp.Upserts, 2)
+	require.Equal(t, 1, server.Calls("/api/vikingdb/collection/info"), "the schema is read once and cached")
+	require.Contains(t, recorder.Last(), `"vec":[0.6,0.8]`)
+
+	// A vector of the wrong dimension is caught after injection, before anything is written.
+	dense = `[0.6,0.8,0]`
+	_, err = collection.UpsertWithEmbedding(context.Background(), request)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "data[0] does not match the collection schema")
+	require.Equal(t, 2, server.Calls(upsertPath))
+
+	// A record the schema rejects for a non-vector field is still reported.
+	dense = `[0.6,0.8]`
+	request.Data = numberedRecords(2)
+	request.Data[1]["tag"] = 7
+	_, err = collection.UpsertWithEmbedding(context.Background(), request)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "data[1] does not match the collection schema")
+	require.Equal(t, 2, server.Calls(upsertPath))
+}
+
+func TestUpdateChangedSendsOnlyTheDelta(t *testing.T) {
+	server := newRoutedServer(t, map[string]func(model.MapStr) scriptedReply{
+		"/api/vikingdb/collection/info": func(model.MapStr) scriptedReply { return scriptedReply{http.StatusOK, describeBody} },
+		"/api/vikingdb/data/fetch_in_collection": func(model.MapStr) scriptedReply {
+			return scriptedReply{http.StatusOK, `{"result":{"fetch":[{"id":7,"fields":{"text":"same","tag":"old","score":1.5}}]}}`}
+		},
+		"/api/vikingdb/data/update": func(model.MapStr) scriptedReply { return scriptedReply{http.StatusOK, okWrite} },
+	})
+	recorder, record := recordBodies(t)
+	collection := testCollection(t, server.URL, record)
+
+	resp, err := collection.UpdateChanged(context.Background(), json.Number("7"), model.MapStr{"id": 99, "text": "same", "tag": "new", "score": 1.5})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.JSONEq(t, `{"collection_name":"collection","data":[{"id":7,"tag":"new"}]}`, recorder.Last())
+
+	resp, err = collection.UpdateChanged(context.Background(), 7, model.MapStr{"text": "same"})
+	require.NoError(t, err)
+	require.Nil(t, resp, "nothing changed, nothing sent")
+	require.Equal(t, 1, server.Calls("/api/vikingdb/data/update"))
+}
+
+func TestUpdateChangedComparesNumbersByValue(t *testing.T) {
+	server := newRoutedServer(t, map[string]func(model.MapStr) scriptedReply{
+		"/api/vikingdb/collection/info": func(model.MapStr) scriptedReply { return scriptedReply{http.StatusOK, describeBody} },
+		"/api/vikingdb/data/fetch_in_collection": func(model.MapStr) scriptedReply {
+			return scriptedReply{http.StatusOK, `{"result":{"fetch":[{"id":7,"fields":{"score":3.0,"ranks":[1,2.50],"meta":{"weight":1e2}}}]}}`}
+		},
+		"/api/vikingdb/data/update": func(model.MapStr) scriptedReply { return scriptedReply{http.StatusOK, okWrite} },
+	})
+	recorder, record := recordBodies(t)
+	collection := testCollection(t, server.URL, record)
+
+	resp, err := collection.UpdateChanged(context.Background(), 7, model.MapStr{"score": 3, "ranks": []float64{1, 2.5}, "meta": model.MapStr{"weight": 100}})
+	require.NoError(t, err)
+	require.Nil(t, resp, "3 equals 3.0, 2.5 equals 2.50 and 100 equals 1e2")
+	require.Equal(t, 0, server.Calls("/api/vikingdb/data/update"))
+
+	_, err = collection.UpdateChanged(context.Background(), 7, model.MapStr{"score": 3.01, "ranks": []float64{1, 2.5}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"collection_name":"collection","data":[{"id":7,"score":3.01}]}`, recorder.Last())
+}
+
+func TestUpdateChangedMissingDocument(t *testing.T) {
+	server := newRoutedServer(t, map[string]func(model.MapStr) scriptedReply{
+		"/api/vikingdb/collection/info": func(model.MapStr) scriptedReply { return scriptedReply{http.StatusOK, describeBody} },
+		"/api/vikingdb/data/fetch_in_collection": func(model.MapStr) scriptedReply {
+			return scriptedReply{http.StatusOK, `{"result":{"ids_not_exist":[7]}}`}
+		},
+	})
+	_, err := testCollection(t, server.URL).UpdateChanged(context.Background(), 7, model.MapStr{"tag": "new"})
+	require.True(t, errors.Is(err, model.ErrDataNotFound), "got %v", err)
+}
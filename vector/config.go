@@ -3,13 +3,27 @@
 package vector
 
 import (
+	"io"
+	"math/rand"
 	"net/http"
 	"time"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/utils"
 )
 
 // Version denotes the SDK version reported via the User-Agent header.
 const Version = "0.1.0"
 
+// JitterStrategy selects how randomness is added to retry backoff delays.
+type JitterStrategy = utils.JitterStrategy
+
+// Jitter strategies accepted by WithJitterStrategy.
+const (
+	FullJitter  = utils.FullJitter
+	EqualJitter = utils.EqualJitter
+	NoJitter    = utils.NoJitter
+)
+
 // Config carries shared settings for all clients.
 type Config struct {
 	Endpoint   string
@@ -18,6 +32,45 @@ type Config struct {
 	MaxRetries int
 	HTTPClient *http.Client
 	UserAgent  string
+	// FloatDecoding decodes response numbers as float64 instead of json.Number.
+	FloatDecoding bool
+	// Jitter selects the retry backoff jitter; FullJitter by default.
+	Jitter JitterStrategy
+	// RandSource seeds retry jitter; a per-client source seeded at creation is used when nil.
+	RandSource *rand.Rand
+	// SparseMaxNonZeros caps the entries of sparse vectors sent in searches and writes; zero disables the cap.
+	SparseMaxNonZeros int
+	// SparsePruneTopK prunes sparse vectors in searches and writes to their k heaviest entries; zero disables pruning.
+	SparsePruneTopK int
+	// ForwardHeaders names the context headers copied onto every request.
+	ForwardHeaders []string
+	// DryRun receives the signed requests instead of sending them when set.
+	DryRun io.Writer
+	// CaptureUnknownFields collects unmodeled top-level response keys into CommonResponse.Extra.
+	CaptureUnknownFields bool
+	// DefaultLimit is used by searches whose Limit is nil; zero leaves the server default.
+	DefaultLimit int
+	// DefaultPartition is used by searches, aggregations, and index fetches that leave Partition empty.
+	DefaultPartition string
+	// OnRetry is called before each retry backoff.
+	OnRetry func(attempt int, err error, nextDelay time.Duration)
+	// EmbeddingCache serves Embedding and EmbeddingCached calls from cache when set.
+	EmbeddingCache EmbeddingCache
+	// SchemaValidation checks written records against the collection schema before sending.
+	SchemaValidation bool
+	// IncludeRequestInErrors caps the request body snapshot attached to failed requests' errors;
+	// zero disables it.
+	IncludeRequestInErrors int
+	// ResponseHook observes every HTTP exchange, successful or not, with the raw response body.
+	ResponseHook ResponseHook
+	// MaxRetryElapsed bounds the total time spent retrying a call; zero means no bound.
+	MaxRetryElapsed time.Duration
+	// StrictFetch makes Fetch fail when any requested id is missing.
+	StrictFetch bool
+	// MaxRequestBytes rejects serialized request bodies larger than this many bytes; zero disables it.
+	MaxRequestBytes int
+	// PathPrefix is prepended to every API path, for services mounted under a non-root path.
+	PathPrefix string
 }
 
 // DefaultConfig returns the baseline configuration.
@@ -68,3 +121,171 @@ func WithUserAgent(userAgent string) ClientOption {
 		c.UserAgent = userAgent
 	}
 }
+
+// WithFloatDecoding decodes response numbers as float64 rather than json.Number.
+// Integers beyond 2^53 lose precision, so avoid it when documents use large int64 ids.
+func WithFloatDecoding() ClientOption {
+	return func(c *Config) {
+		c.FloatDecoding = true
+	}
+}
+
+// WithDryRun builds and signs every request, writes it to w with credentials masked, and returns
+// ErrDryRun without contacting the server.
+func WithDryRun(w io.Writer) ClientOption {
+	return func(c *Config) {
+		c.DryRun = w
+	}
+}
+
+// WithJitterStrategy selects the jitter applied to retry backoff delays.
+func WithJitterStrategy(strategy JitterStrategy) ClientOption {
+	return func(c *Config) {
+		c.Jitter = strategy
+	}
+}
+
+// WithRandSource injects the random source used for retry jitter, e.g. a fixed seed in tests.
+// The client serializes access, so the same source must not be shared with other code.
+func WithRandSource(r *rand.Rand) ClientOption {
+	return func(c *Config) {
+		c.RandSource = r
+	}
+}
+
+// WithForwardHeaders copies the named headers from the http.Header stored via ContextWithForwardHeaders
+// onto every request. Per-request headers set with WithRequestHeader take precedence. Do not forward
+// Accept-Encoding: it turns off net/http's transparent gzip decompression.
+func WithForwardHeaders(names ...string) ClientOption {
+	return func(c *Config) {
+		c.ForwardHeaders = append(c.ForwardHeaders, names...)
+	}
+}
+
+// WithSparsePruneTopK keeps only the k heaviest entries of every sparse vector sent in searches and
+// writes, using SparseVector.PruneTopK. Pruning runs before the WithSparseMaxNonZeros check.
+func WithSparsePruneTopK(k int) ClientOption {
+	return func(c *Config) {
+		c.SparsePruneTopK = k
+	}
+}
+
+// WithSparseMaxNonZeros rejects searches and writes carrying a sparse vector with more than n entries,
+// before the request is sent.
+func WithSparseMaxNonZeros(n int) ClientOption {
+	return func(c *Config) {
+		c.SparseMaxNonZeros = n
+	}
+}
+
+// WithCaptureUnknownFields collects top-level response keys the SDK does not model into
+// CommonResponse.Extra, so new server fields can be inspected before the SDK supports them.
+// Responses are decoded twice, so leave it off on hot paths.
+func WithCaptureUnknownFields() ClientOption {
+	return func(c *Config) {
+		c.CaptureUnknownFields = true
+	}
+}
+
+// WithDefaultLimit sets the hit count of searches that leave Limit nil, so callers need not take the
+// address of an int for every request.
+func WithDefaultLimit(n int) ClientOption {
+	return func(c *Config) {
+		c.DefaultLimit = n
+	}
+}
+
+// WithDefaultPartition routes searches, aggregations, and index fetches that leave Partition empty
+// to partition. Writes carry the partition as a record field and are not affected.
+func WithDefaultPartition(partition string) ClientOption {
+	return func(c *Config) {
+		c.DefaultPartition = partition
+	}
+}
+
+// WithOnRetry registers fn to be called before each retry backoff with the retry number (starting at
+// 1), the error that triggered it, and the delay before the next attempt. fn runs on the request's
+// goroutine and should return quickly.
+func WithOnRetry(fn func(attempt int, err error, nextDelay time.Duration)) ClientOption {
+	return func(c *Config) {
+		c.OnRetry = fn
+	}
+}
+
+// WithEmbeddingCache makes Embedding read through cache: data items embedded before with the same
+// models are served from it and only the rest are sent. NewLRUEmbeddingCache provides an in-memory
+// implementation.
+func WithEmbeddingCache(cache EmbeddingCache) ClientOption {
+	return func(c *Config) {
+		c.EmbeddingCache = cache
+	}
+}
+
+// WithSchemaValidation checks every upserted or updated record with model.ValidateRecord before it
+// is sent. The schema is read with Describe on the first write to each collection and cached for the
+// client's lifetime, so recreate the client after changing a collection's schema.
+func WithSchemaValidation(enabled bool) ClientOption {
+	return func(c *Config) {
+		c.SchemaValidation = enabled
+	}
+}
+
+// WithIncludeRequestInErrors attaches up to maxBytes of the request body to the *model.Error of a
+// failed request, as Error.RequestBody, to help reproduce it. Values of keys that look like secrets,
+// such as "api_key" or "token", are masked.
+func WithIncludeRequestInErrors(maxBytes int) ClientOption {
+	return func(c *Config) {
+		c.IncludeRequestInErrors = maxBytes
+	}
+}
+
+// ResponseHook receives each HTTP attempt once it completes. resp and body are nil when the request
+// never got a response; err is the error the attempt returns, nil on success. The request body can be
+// re-read through req.GetBody.
+//
+// The hook sees payloads exactly as sent and received, credentials and stored fields included: redact
+// before writing them to an audit sink. Neither req, resp, nor body may be modified, and body must not
+// be retained after the hook returns.
+type ResponseHook func(req *http.Request, resp *http.Response, body []byte, err error)
+
+// WithResponseHook calls hook after every HTTP attempt, retries included, for audit logging.
+func WithResponseHook(hook ResponseHook) ClientOption {
+	return func(c *Config) {
+		c.ResponseHook = hook
+	}
+}
+
+// WithMaxRetryElapsed stops retrying a call once the next backoff would take it past d since the first
+// attempt, returning the last error. It complements WithMaxRetries, which caps the attempt count.
+func WithMaxRetryElapsed(d time.Duration) ClientOption {
+	return func(c *Config) {
+		c.MaxRetryElapsed = d
+	}
+}
+
+// WithStrictFetch makes collection and index Fetch return a *model.DataNotFoundError, matching
+// model.ErrDataNotFound, when any requested id does not exist. The response with the records that were
+// found is still returned alongside the error.
+func WithStrictFetch() ClientOption {
+	return func(c *Config) {
+		c.StrictFetch = true
+	}
+}
+
+// WithMaxRequestBytes fails requests whose serialized body exceeds n bytes with an InvalidParameter
+// error before anything is signed or sent, instead of a late 413 from the server. Split large writes
+// with WriteDataBase.BatchSize.
+func WithMaxRequestBytes(n int) ClientOption {
+	return func(c *Config) {
+		c.MaxRequestBytes = n
+	}
+}
+
+// WithPathPrefix prepends prefix to every API path, so "/api/vikingdb/data/upsert" is sent as
+// prefix+"/api/vikingdb/data/upsert". Use it when an ingress or gateway mounts the service under a
+// non-root path such as "/vikingdb".
+func WithPathPrefix(prefix string) ClientOption {
+	return func(c *Config) {
+		c.PathPrefix = prefix
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+// DecodeHits decodes each hit's Fields into a T, matching fields by their json tags, and returns them
+// in ranking order. Embed model.HitMeta in T to also receive each hit's id and scores:
+//
+//	type Chapter struct {
+//		model.HitMeta
+//		Title string `json:"title"`
+//	}
+//	chapters, err := vector.DecodeHits[Chapter](resp.Result)
+func DecodeHits[T any](result *model.SearchResult) ([]T, error) {
+	if result == nil {
+		return nil, nil
+	}
+	out := make([]T, len(result.Data))
+	for idx, hit := range result.Data {
+		payload, err := json.Marshal(hit.Fields)
+		if err != nil {
+			return nil, model.NewErrorWithCause(model.ErrCodeUnknown, fmt.Sprintf("failed to encode fields of hit %d", idx), err, http.StatusInternalServerError)
+		}
+		if err := json.Unmarshal(payload, &out[idx]); err != nil {
+			return nil, model.NewErrorWithCause(model.ErrCodeInvalidParameter, fmt.Sprintf("failed to decode hit %d into %T", idx, out[idx]), err, http.StatusBadRequest)
+		}
+		if meta, ok := any(&out[idx]).(interface{ SetHitMeta(model.SearchItemResult) }); ok {
+			meta.SetHitMeta(hit)
+		}
+	}
+	return out, nil
+}
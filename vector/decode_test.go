@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+func TestDecodeHits(t *testing.T) {
+	type chapter struct {
+		model.HitMeta
+		Title     string `json:"title"`
+		Paragraph int64  `json:"paragraph"`
+	}
+	result := &model.SearchResult{Data: []model.SearchItemResult{
+		{ID: json.Number("42"), Score: 0.9, Fields: model.MapStr{"title": "intro", "paragraph": json.Number("3")}},
+		{ID: "doc-2", Score: 0.5, Fields: model.MapStr{"title": "outro"}},
+	}}
+
+	chapters, err := DecodeHits[chapter](result)
+	require.NoError(t, err)
+	require.Len(t, chapters, 2)
+	require.Equal(t, "intro", chapters[0].Title)
+	require.Equal(t, int64(3), chapters[0].Paragraph)
+	require.Equal(t, json.Number("42"), chapters[0].ID)
+	require.Equal(t, float32(0.9), chapters[0].Score)
+	require.Equal(t, "doc-2", chapters[1].ID)
+
+	_, err = DecodeHits[chapter](&model.SearchResult{Data: []model.SearchItemResult{
+		{ID: 1, Fields: model.MapStr{"paragraph": "not a number"}},
+	}})
+	require.Error(t, err)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+// defaultEmbeddingCacheSize bounds the per-client cache used by EmbeddingCached when no
+// EmbeddingCache is configured.
+const defaultEmbeddingCacheSize = 1024
+
+// EmbeddingCache stores embeddings of single data items. Keys are opaque hashes of the project, the
+// models and the content, computed by the SDK. The SDK copies embeddings before Set and after Get, so
+// implementations may keep the pointers they are given. Implementations must be safe for concurrent use.
+type EmbeddingCache interface {
+	Get(key string) (*model.Embedding, bool)
+	Set(key string, embedding *model.Embedding)
+}
+
+// LRUEmbeddingCache is an in-memory EmbeddingCache that evicts the least recently used entry once it
+// holds size entries.
+type LRUEmbeddingCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key       string
+	embedding *model.Embedding
+}
+
+// NewLRUEmbeddingCache returns an LRU cache holding at most size embeddings; size below 1 is
+// treated as 1.
+func NewLRUEmbeddingCache(size int) *LRUEmbeddingCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUEmbeddingCache{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+// Get returns the cached embedding and marks it as recently used.
+func (c *LRUEmbeddingCache) Get(key string) (*model.Embedding, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).embedding, true
+}
+
+// Set stores the embedding, evicting the least recently used entry when full.
+func (c *LRUEmbeddingCache) Set(key string, embedding *model.Embedding) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry).embedding = embedding
+		c.order.MoveToFront(elem)
+		return
+	}
+	for c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, embedding: embedding})
+}
+
+// Len returns the number of cached embeddings.
+func (c *LRUEmbeddingCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// embeddingCacheKey hashes the project, the models and the content of one data item. Any change to
+// the project, model name, version, dimension, or normalization yields a different key.
+func embeddingCacheKey(project *string, dense, sparse *model.EmbeddingModelOpt, data *model.EmbeddingData) (string, error) {
+	payload, err := json.Marshal(struct {
+		Project *string                  `json:"project,omitempty"`
+		Dense   *model.EmbeddingModelOpt `json:"dense,omitempty"`
+		Sparse  *model.EmbeddingModelOpt `json:"sparse,omitempty"`
+		Data    *model.EmbeddingData     `json:"data"`
+	}{project, dense, sparse, data})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// cloneEmbedding deep-copies the vectors of e so cached entries never alias caller-owned slices.
+func cloneEmbedding(e *model.Embedding) *model.Embedding {
+	if e == nil {
+		return nil
+	}
+	out := &model.Embedding{}
+	if e.DenseVectors != nil {
+		out.DenseVectors = append([]float32(nil), e.DenseVectors...)
+	}
+	if e.SparseVectors != nil {
+		out.SparseVectors = make(map[string]float32, len(e.SparseVectors))
+		for k, v := range e.SparseVectors {
+			out.SparseVectors[k] = v
+		}
+	}
+	if e.Named != nil {
+		out.Named = make(map[string]*model.Embedding, len(e.Named))
+		for k, v := range e.Named {
+			out.Named[k] = cloneEmbedding(v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+func TestLRUEmbeddingCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewLRUEmbeddingCache(2)
+	cache.Set("a", &model.Embedding{DenseVectors: []float32{1}})
+	cache.Set("b", &model.Embedding{DenseVectors: []float32{2}})
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	cache.Set("c", &model.Embedding{DenseVectors: []float32{3}})
+	_, ok = cache.Get("b")
+	require.False(t, ok, "b was least recently used")
+	_, ok = cache.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 2, cache.Len())
+}
+
+func TestEmbeddingReadsThroughCache(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, `{"request_id":"req-1","result":{"data":[{"dense":[0.1,0.2]}]}}`},
+	)
+	client, err := New(AuthAPIKey("test-key"), WithEndpoint(server.URL), WithEmbeddingCache(NewLRUEmbeddingCache(8)))
+	require.NoError(t, err)
+
+	name, text := "bge-m3", "cached text"
+	request := model.EmbeddingRequest{
+		DenseModel: &model.EmbeddingModelOpt{ModelName: &name},
+		Data:       []*model.EmbeddingData{{Text: &text}},
+	}
+	first, err := client.Embedding().Embedding(context.Background(), request)
+	require.NoError(t, err)
+	second, err := client.Embedding().Embedding(context.Background(), request)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, server.Calls(), "second call should be served from cache")
+	require.Equal(t, first.Result.Data[0].DenseVectors, second.Result.Data[0].DenseVectors)
+	require.Nil(t, second.Result.TokenUsage)
+}
+
+func TestDefaultEmbeddingCacheIsAllocatedOnFirstUse(t *testing.T) {
+	client := newTestClient(t, "http://127.0.0.1")
+	require.Nil(t, client.transport.embeddingCache, "no cache until EmbeddingCached is called")
+	require.NotNil(t, client.transport.cache())
+}
+
+func TestEmbeddingCachedReturnsCopies(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, `{"request_id":"req-1","result":{"data":[{"dense":[0.1,0.2]}]}}`},
+	)
+	client := newTestClient(t, server.URL)
+	request := textEmbeddingRequest("mutable")
+
+	first, err := client.Embedding().EmbeddingCached(context.Background(), request)
+	require.NoError(t, err)
+	first.Result.Data[0].DenseVectors[0] = 9
+
+	second, err := client.Embedding().EmbeddingCached(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, 1, server.Calls())
+	require.Equal(t, []float32{0.1, 0.2}, second.Result.Data[0].DenseVectors)
+	second.Result.Data[0].DenseVectors[1] = 9
+
+	third, err := client.Embedding().EmbeddingCached(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, []float32{0.1, 0.2}, third.Result.Data[0].DenseVectors)
+}
+
+func TestEmbeddingCacheKeyIncludesProject(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, `{"request_id":"req-1","result":{"data":[{"dense":[0.1,0.2]}]}}`},
+	)
+	client := newTestClient(t, server.URL)
+	projectA, projectB := "project-a", "project-b"
+
+	request := textEmbeddingRequest("shared text")
+	request.ProjectName = &projectA
+	_, err := client.Embedding().EmbeddingCached(context.Background(), request)
+	require.NoError(t, err)
+	request.ProjectName = &projectB
+	_, err = client.Embedding().EmbeddingCached(context.Background(), request)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, server.Calls(), "each project embeds the text with its own models")
+}
@@ -5,6 +5,8 @@ package vector
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/volcengine/vikingdb-go-sdk/vector/model"
@@ -15,7 +17,164 @@ type embeddingClient struct {
 }
 
 func (e *embeddingClient) Embedding(ctx context.Context, request model.EmbeddingRequest, opts ...RequestOption) (*model.EmbeddingResponse, error) {
+	if e.client.config.EmbeddingCache != nil && len(request.Models) == 0 {
+		return e.EmbeddingCached(ctx, request, opts...)
+	}
+	return e.embed(ctx, request, opts...)
+}
+
+// embed sends the request without consulting the cache.
+func (e *embeddingClient) embed(ctx context.Context, request model.EmbeddingRequest, opts ...RequestOption) (*model.EmbeddingResponse, error) {
+	if len(request.Models) > 0 {
+		response, err := e.embedNamed(ctx, request, opts...)
+		return response, classifyEmbeddingError(classifyQuotaError(err))
+	}
 	response := &model.EmbeddingResponse{}
 	err := e.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/embedding", request, response, opts...)
-	return response, err
+	return response, classifyEmbeddingError(classifyQuotaError(err))
+}
+
+// EmbeddingCached behaves like Embedding but serves data items embedded earlier by this client with
+// the same models from the client's EmbeddingCache, and only sends the remaining items to the server.
+// Without WithEmbeddingCache an in-memory LRU of 1024 entries is allocated on first use. Entries are
+// keyed by project, models and content, and are copied in and out of the cache, so callers may modify
+// returned embeddings. TokenUsage covers the items actually sent, and is empty when every item was
+// cached. Requests using Models bypass the cache.
+func (e *embeddingClient) EmbeddingCached(ctx context.Context, request model.EmbeddingRequest, opts ...RequestOption) (*model.EmbeddingResponse, error) {
+	if len(request.Models) > 0 {
+		return e.embed(ctx, request, opts...)
+	}
+
+	cache := e.client.cache()
+	keys := make([]string, len(request.Data))
+	data := make([]*model.Embedding, len(request.Data))
+	missing := make([]int, 0, len(request.Data))
+	for idx, item := range request.Data {
+		key, err := embeddingCacheKey(request.ProjectName, request.DenseModel, request.SparseModel, item)
+		if err != nil {
+			return nil, model.NewErrorWithCause(model.ErrCodeInvalidParameter, "failed to hash embedding data", err, http.StatusBadRequest)
+		}
+		keys[idx] = key
+		if embedding, ok := cache.Get(key); ok {
+			data[idx] = cloneEmbedding(embedding)
+			continue
+		}
+		missing = append(missing, idx)
+	}
+
+	response := &model.EmbeddingResponse{Result: &model.EmbeddingResult{Data: data}}
+	if len(missing) == 0 {
+		return response, nil
+	}
+
+	pending := request
+	pending.Data = make([]*model.EmbeddingData, len(missing))
+	for i, idx := range missing {
+		pending.Data[i] = request.Data[idx]
+	}
+	fetched, err := e.embed(ctx, pending, opts...)
+	if err != nil {
+		return fetched, err
+	}
+	if fetched.Result == nil || len(fetched.Result.Data) != len(missing) {
+		return nil, model.NewError(model.ErrCodeEmbeddingFailed, "embedding returned an unexpected number of vectors")
+	}
+	for i, idx := range missing {
+		data[idx] = fetched.Result.Data[i]
+		cache.Set(keys[idx], cloneEmbedding(fetched.Result.Data[i]))
+	}
+	response.CommonResponse = fetched.CommonResponse
+	response.Result.TokenUsage = fetched.Result.TokenUsage
+	return response, nil
+}
+
+// modelNotFoundCodes are the exact server codes for an unknown or unsupported embedding model.
+var modelNotFoundCodes = map[model.ErrorCode]bool{
+	"ModelNotExists":    true,
+	"ModelNotSupported": true,
+}
+
+// classifyEmbeddingError maps the server's unknown-model codes onto ErrCodeModelNotFound, keeping the
+// server status and the original error as the cause. Messages are never inspected.
+func classifyEmbeddingError(err error) error {
+	var sdkErr *model.Error
+	if !errors.As(err, &sdkErr) || !modelNotFoundCodes[sdkErr.Code] {
+		return err
+	}
+	return &model.Error{
+		Code:       model.ErrCodeModelNotFound,
+		Message:    sdkErr.Message,
+		StatusCode: sdkErr.StatusCode,
+		RequestID:  sdkErr.RequestID,
+		Err:        sdkErr,
+	}
+}
+
+// embedNamed fans the request out to one call per named model, a few at a time, and merges the vectors
+// by data index and the token usage by model. Any failing model fails the whole call.
+func (e *embeddingClient) embedNamed(ctx context.Context, request model.EmbeddingRequest, opts ...RequestOption) (*model.EmbeddingResponse, error) {
+	if request.DenseModel != nil || request.SparseModel != nil {
+		return nil, model.NewInvalidParameterError("models cannot be combined with dense_model or sparse_model")
+	}
+	seen := make(map[string]struct{}, len(request.Models))
+	for _, m := range request.Models {
+		if m.Name == "" {
+			return nil, model.NewInvalidParameterError("model name cannot be empty")
+		}
+		if m.DenseModel == nil && m.SparseModel == nil {
+			return nil, model.NewInvalidParameterError(fmt.Sprintf("model %s needs a dense or sparse model", m.Name))
+		}
+		if _, ok := seen[m.Name]; ok {
+			return nil, model.NewInvalidParameterError(fmt.Sprintf("duplicate model name %s", m.Name))
+		}
+		seen[m.Name] = struct{}{}
+	}
+
+	responses := make([]*model.EmbeddingResponse, len(request.Models))
+	errs := make([]error, len(request.Models))
+	fanOut(len(request.Models), 0, func(idx int) {
+		m := request.Models[idx]
+		single := model.EmbeddingRequest{
+			ProjectName: request.ProjectName,
+			DenseModel:  m.DenseModel,
+			SparseModel: m.SparseModel,
+			Data:        request.Data,
+		}
+		responses[idx] = &model.EmbeddingResponse{}
+		errs[idx] = e.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/embedding", single, responses[idx], opts...)
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	merged := make([]*model.Embedding, len(request.Data))
+	for i := range merged {
+		merged[i] = &model.Embedding{Named: make(map[string]*model.Embedding, len(request.Models))}
+	}
+	usage := model.TokenUsage{}
+	for idx, m := range request.Models {
+		result := responses[idx].Result
+		if result == nil || len(result.Data) != len(request.Data) {
+			return nil, model.NewError(model.ErrCodeEmbeddingFailed, fmt.Sprintf("model %s returned an unexpected number of vectors", m.Name))
+		}
+		for i, embedding := range result.Data {
+			merged[i].Named[m.Name] = embedding
+		}
+		modelUsage, err := model.ParseTokenUsage(result.TokenUsage)
+		if err != nil {
+			return nil, model.NewErrorWithCause(model.ErrCodeUnknown, fmt.Sprintf("model %s returned malformed token usage", m.Name), err, http.StatusInternalServerError)
+		}
+		usage.Add(modelUsage)
+	}
+
+	return &model.EmbeddingResponse{
+		CommonResponse: responses[0].CommonResponse,
+		Result: &model.EmbeddingResult{
+			Data:       merged,
+			TokenUsage: usage,
+		},
+	}, nil
 }
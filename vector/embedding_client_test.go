@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+func textEmbeddingRequest(texts ...string) model.EmbeddingRequest {
+	name := "bge-m3"
+	request := model.EmbeddingRequest{DenseModel: &model.EmbeddingModelOpt{ModelName: &name}}
+	for i := range texts {
+		request.Data = append(request.Data, &model.EmbeddingData{Text: &texts[i]})
+	}
+	return request
+}
+
+func TestEmbeddingQuotaWordedThrottlingIsRetried(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusTooManyRequests, `{"code":"RequestLimitExceeded","message":"QPS quota exceeded, slow down"}`},
+		scriptedReply{http.StatusOK, `{"request_id":"req-ok","result":{"data":[{"dense":[0.1]}]}}`},
+	)
+	resp, err := newTestClient(t, server.URL).Embedding().Embedding(context.Background(), textEmbeddingRequest("a"))
+	require.NoError(t, err)
+	require.Equal(t, 2, server.Calls())
+	require.Equal(t, "req-ok", resp.RequestID)
+}
+
+func TestEmbeddingQuotaExhaustedIsNotRetried(t *testing.T) {
+	cases := []struct {
+		name  string
+		reply scriptedReply
+	}{
+		{"payment required", scriptedReply{http.StatusPaymentRequired, `{"code":"Forbidden","message":"pay up"}`}},
+		{"arrears code", scriptedReply{http.StatusForbidden, `{"code":"AccountOverdue","message":"account overdue"}`}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newScriptedServer(t, tc.reply)
+			_, err := newTestClient(t, server.URL).Embedding().Embedding(context.Background(), textEmbeddingRequest("a"))
+			require.True(t, errors.Is(err, model.ErrQuotaExceeded), "got %v", err)
+			require.Equal(t, 1, server.Calls())
+
+			var sdkErr *model.Error
+			require.True(t, errors.As(err, &sdkErr))
+			require.Equal(t, tc.reply.status, sdkErr.StatusCode)
+		})
+	}
+}
+
+func TestQuotaWordingOutsideModelPathsIsUntouched(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusBadRequest, `{"code":"InvalidParameter","message":"billing field is not indexed"}`},
+	)
+	_, err := newTestIndexClient(t, server.URL).SearchByRandom(context.Background(), randomSearch())
+	require.True(t, errors.Is(err, model.NewInvalidParameterError("")), "got %v", err)
+	require.False(t, errors.Is(err, model.ErrQuotaExceeded))
+}
+
+func TestEmbeddingModelErrorsAreClassifiedByCode(t *testing.T) {
+	cases := []struct {
+		name         string
+		reply        scriptedReply
+		wantNotFound bool
+	}{
+		{"model not exists", scriptedReply{http.StatusNotFound, `{"code":"ModelNotExists","message":"model bge-x not exist"}`}, true},
+		{"model not supported", scriptedReply{http.StatusBadRequest, `{"code":"ModelNotSupported","message":"unsupported"}`}, true},
+		{"model-worded parameter error", scriptedReply{http.StatusBadRequest, `{"code":"InvalidParameter","message":"model field not found in data"}`}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newScriptedServer(t, tc.reply)
+			_, err := newTestClient(t, server.URL).Embedding().Embedding(context.Background(), textEmbeddingRequest("a"))
+			require.Equal(t, tc.wantNotFound, errors.Is(err, model.ErrModelNotFound), "got %v", err)
+
+			var sdkErr *model.Error
+			require.True(t, errors.As(err, &sdkErr))
+			require.Equal(t, tc.reply.status, sdkErr.StatusCode)
+		})
+	}
+}
+
+// modelRoutedServer answers each embedding request with the reply registered for its dense model name.
+func modelRoutedServer(t *testing.T, replies map[string]scriptedReply) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			DenseModel struct {
+				Name string `json:"name"`
+			} `json:"dense_model"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		reply, ok := replies[body.DenseModel.Name]
+		require.True(t, ok, "unexpected model %q", body.DenseModel.Name)
+		w.WriteHeader(reply.status)
+		_, _ = w.Write([]byte(reply.body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func namedModelsRequest(names ...string) model.EmbeddingRequest {
+	request := textEmbeddingRequest("a")
+	request.DenseModel = nil
+	for i := range names {
+		request.Models = append(request.Models, model.NamedEmbeddingModel{
+			Name:       names[i],
+			DenseModel: &model.EmbeddingModelOpt{ModelName: &names[i]},
+		})
+	}
+	return request
+}
+
+func TestNamedEmbeddingMergesVectorsAndUsage(t *testing.T) {
+	server := modelRoutedServer(t, map[string]scriptedReply{
+		"small": {http.StatusOK, `{"result":{"data":[{"dense":[0.1]}],"token_usage":{"small__1":{"prompt_tokens":3,"total_tokens":3}}}}`},
+		"large": {http.StatusOK, `{"result":{"data":[{"dense":[0.2,0.3]}],"token_usage":{"large__1":{"prompt_tokens":5,"total_tokens":5}}}}`},
+	})
+	resp, err := newTestClient(t, server.URL).Embedding().Embedding(context.Background(), namedModelsRequest("small", "large"))
+	require.NoError(t, err)
+
+	named := resp.Result.Data[0].Named
+	require.Equal(t, []float32{0.1}, named["small"].DenseVectors)
+	require.Equal(t, []float32{0.2, 0.3}, named["large"].DenseVectors)
+
+	usage, ok := resp.Result.TokenUsage.(model.TokenUsage)
+	require.True(t, ok, "got %T", resp.Result.TokenUsage)
+	require.Equal(t, int64(3), usage["small__1"].PromptTokens)
+	require.Equal(t, int64(8), usage.Total())
+}
+
+func TestNamedEmbeddingFailsWhenOneModelFails(t *testing.T) {
+	server := modelRoutedServer(t, map[string]scriptedReply{
+		"small": {http.StatusOK, `{"result":{"data":[{"dense":[0.1]}]}}`},
+		"large": {http.StatusBadRequest, `{"code":"InvalidParameter","message":"dim too large"}`},
+	})
+	resp, err := newTestClient(t, server.URL).Embedding().Embedding(context.Background(), namedModelsRequest("small", "large"))
+	require.True(t, errors.Is(err, model.NewInvalidParameterError("")), "got %v", err)
+	require.Nil(t, resp)
+}
+
+func TestEmbeddingNormalizeWireFormat(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, `{"result":{"data":[{"dense":[0.6,0.8]}]}}`})
+	recorder, record := recordBodies(t)
+	embedding := newTestClient(t, server.URL, record).Embedding()
+
+	request := textEmbeddingRequest("a")
+	normalize := true
+	request.DenseModel.Normalize = &normalize
+	_, err := embedding.Embedding(context.Background(), request)
+	require.NoError(t, err)
+	require.Contains(t, recorder.Last(), `"normalize":true`)
+
+	request.DenseModel.Normalize = nil
+	_, err = embedding.Embedding(context.Background(), request)
+	require.NoError(t, err)
+	require.NotContains(t, recorder.Last(), `"normalize"`)
+}
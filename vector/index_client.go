@@ -5,6 +5,10 @@ package vector
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/volcengine/vikingdb-go-sdk/vector/model"
@@ -16,6 +20,25 @@ type indexClient struct {
 }
 
 func (i *indexClient) Fetch(ctx context.Context, request model.FetchDataInIndexRequest, opts ...RequestOption) (*model.FetchDataInIndexResponse, error) {
+	response, err := i.fetch(ctx, request, opts...)
+	if err == nil && i.transport.config.StrictFetch && response.Result != nil && len(response.Result.NotFoundIDs) > 0 {
+		err = model.NewDataNotFoundError(response.Result.NotFoundIDs)
+	}
+	return response, err
+}
+
+// fetch is Fetch without WithStrictFetch, for hydrating hits that may have been deleted since the
+// search.
+func (i *indexClient) fetch(ctx context.Context, request model.FetchDataInIndexRequest, opts ...RequestOption) (*model.FetchDataInIndexResponse, error) {
+	if err := i.indexBase.Validate(); err != nil {
+		return nil, err
+	}
+	i.applyDefaultPartition(&request.Partition)
+	ids, err := model.CanonicalIDs(request.IDs)
+	if err != nil {
+		return nil, err
+	}
+	request.IDs = ids
 	response := &model.FetchDataInIndexResponse{}
 	req := struct {
 		model.IndexLocator
@@ -24,24 +47,45 @@ func (i *indexClient) Fetch(ctx context.Context, request model.FetchDataInIndexR
 		IndexLocator:            i.indexBase,
 		FetchDataInIndexRequest: request,
 	}
-	err := i.transport.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/fetch_in_index", req, response, opts...)
+	err = i.transport.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/fetch_in_index", req, response, opts...)
 	return response, err
 }
 
 func (i *indexClient) SearchByVector(ctx context.Context, request model.SearchByVectorRequest, opts ...RequestOption) (*model.SearchResponse, error) {
+	if err := i.indexBase.Validate(); err != nil {
+		return nil, err
+	}
+	i.applySearchDefaults(&request.SearchBase)
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	request.SparseVector = request.SparseVector.PruneTopK(i.transport.config.SparsePruneTopK)
+	if err := request.SparseVector.Validate(i.transport.config.SparseMaxNonZeros); err != nil {
+		return nil, err
+	}
 	response := &model.SearchResponse{}
+	// DenseVector shadows the embedded field so either representation goes out as dense_vector.
 	req := struct {
 		model.IndexLocator
 		model.SearchByVectorRequest
+		DenseVector interface{} `json:"dense_vector"`
 	}{
 		IndexLocator:          i.indexBase,
 		SearchByVectorRequest: request,
+		DenseVector:           request.DenseVector,
+	}
+	if len(request.DenseVectorF32) > 0 {
+		req.DenseVector = request.DenseVectorF32
 	}
 	err := i.transport.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/search/vector", req, response, opts...)
-	return response, err
+	return response, classifySearchError(err)
 }
 
 func (i *indexClient) SearchByMultiModal(ctx context.Context, request model.SearchByMultiModalRequest, opts ...RequestOption) (*model.SearchResponse, error) {
+	if err := i.indexBase.Validate(); err != nil {
+		return nil, err
+	}
+	i.applySearchDefaults(&request.SearchBase)
 	response := &model.SearchResponse{}
 	req := struct {
 		model.IndexLocator
@@ -51,10 +95,14 @@ func (i *indexClient) SearchByMultiModal(ctx context.Context, request model.Sear
 		SearchByMultiModalRequest: request,
 	}
 	err := i.transport.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/search/multi_modal", req, response, opts...)
-	return response, err
+	return response, classifySearchError(err)
 }
 
 func (i *indexClient) SearchByID(ctx context.Context, request model.SearchByIDRequest, opts ...RequestOption) (*model.SearchResponse, error) {
+	if err := i.indexBase.Validate(); err != nil {
+		return nil, err
+	}
+	i.applySearchDefaults(&request.SearchBase)
 	response := &model.SearchResponse{}
 	req := struct {
 		model.IndexLocator
@@ -64,10 +112,17 @@ func (i *indexClient) SearchByID(ctx context.Context, request model.SearchByIDRe
 		SearchByIDRequest: request,
 	}
 	err := i.transport.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/search/id", req, response, opts...)
-	return response, err
+	return response, classifySearchError(err)
 }
 
 func (i *indexClient) SearchByScalar(ctx context.Context, request model.SearchByScalarRequest, opts ...RequestOption) (*model.SearchResponse, error) {
+	if err := i.indexBase.Validate(); err != nil {
+		return nil, err
+	}
+	i.applySearchDefaults(&request.SearchBase)
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
 	response := &model.SearchResponse{}
 	req := struct {
 		model.IndexLocator
@@ -77,10 +132,17 @@ func (i *indexClient) SearchByScalar(ctx context.Context, request model.SearchBy
 		SearchByScalarRequest: request,
 	}
 	err := i.transport.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/search/scalar", req, response, opts...)
-	return response, err
+	return response, classifySearchError(err)
 }
 
 func (i *indexClient) SearchByKeywords(ctx context.Context, request model.SearchByKeywordsRequest, opts ...RequestOption) (*model.SearchResponse, error) {
+	if err := i.indexBase.Validate(); err != nil {
+		return nil, err
+	}
+	i.applySearchDefaults(&request.SearchBase)
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
 	response := &model.SearchResponse{}
 	req := struct {
 		model.IndexLocator
@@ -90,10 +152,14 @@ func (i *indexClient) SearchByKeywords(ctx context.Context, request model.Search
 		SearchByKeywordsRequest: request,
 	}
 	err := i.transport.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/search/keywords", req, response, opts...)
-	return response, err
+	return response, classifySearchError(err)
 }
 
 func (i *indexClient) SearchByRandom(ctx context.Context, request model.SearchByRandomRequest, opts ...RequestOption) (*model.SearchResponse, error) {
+	if err := i.indexBase.Validate(); err != nil {
+		return nil, err
+	}
+	i.applySearchDefaults(&request.SearchBase)
 	response := &model.SearchResponse{}
 	req := struct {
 		model.IndexLocator
@@ -103,10 +169,306 @@ func (i *indexClient) SearchByRandom(ctx context.Context, request model.SearchBy
 		SearchByRandomRequest: request,
 	}
 	err := i.transport.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/search/random", req, response, opts...)
-	return response, err
+	return response, classifySearchError(err)
+}
+
+// applySearchDefaults fills a nil Limit with the client's DefaultLimit and an empty Partition with
+// its DefaultPartition.
+func (i *indexClient) applySearchDefaults(base *model.SearchBase) {
+	if base.Limit == nil && i.transport.config.DefaultLimit > 0 {
+		limit := i.transport.config.DefaultLimit
+		base.Limit = &limit
+	}
+	i.applyDefaultPartition(&base.Partition)
+}
+
+// applyDefaultPartition fills an empty partition with the client's DefaultPartition.
+func (i *indexClient) applyDefaultPartition(partition *string) {
+	if *partition == "" {
+		*partition = i.transport.config.DefaultPartition
+	}
+}
+
+// searchWindowErrorCodes are the exact server codes for an offset + limit beyond the search window.
+var searchWindowErrorCodes = map[model.ErrorCode]bool{
+	"SearchWindowExceeded": true,
+	"OffsetExceedsLimit":   true,
+}
+
+// classifySearchError turns the server's pagination-window rejection into an InvalidParameter error
+// that names the constraint, keeping the server status and the original error as the cause.
+func classifySearchError(err error) error {
+	var sdkErr *model.Error
+	if !errors.As(err, &sdkErr) || !searchWindowErrorCodes[sdkErr.Code] {
+		return err
+	}
+	return &model.Error{
+		Code:       model.ErrCodeInvalidParameter,
+		Message:    "offset + limit exceeds the maximum search window; narrow the query with a filter instead of paging deeper: " + sdkErr.Message,
+		StatusCode: sdkErr.StatusCode,
+		RequestID:  sdkErr.RequestID,
+		Err:        sdkErr,
+	}
+}
+
+// fetchBatchSize caps the ids sent per Fetch call by SearchAndFetch.
+const fetchBatchSize = 100
+
+// SearchAndFetch runs request, any of the model.SearchBy*Request values, then fetches the
+// hits by id and replaces each hit's fields with the fetched document projected to outputFields (all
+// fields when empty). Hits keep their ranking and scores; hits that vanished between the two calls are dropped.
+func (i *indexClient) SearchAndFetch(ctx context.Context, request model.SearchRequest, outputFields []string, opts ...RequestOption) (*model.SearchResponse, error) {
+	var resp *model.SearchResponse
+	var err error
+	switch req := request.(type) {
+	case model.SearchByVectorRequest:
+		resp, err = i.SearchByVector(ctx, req, opts...)
+	case model.SearchByMultiModalRequest:
+		resp, err = i.SearchByMultiModal(ctx, req, opts...)
+	case model.SearchByIDRequest:
+		resp, err = i.SearchByID(ctx, req, opts...)
+	case model.SearchByScalarRequest:
+		resp, err = i.SearchByScalar(ctx, req, opts...)
+	case model.SearchByKeywordsRequest:
+		resp, err = i.SearchByKeywords(ctx, req, opts...)
+	case model.SearchByRandomRequest:
+		resp, err = i.SearchByRandom(ctx, req, opts...)
+	default:
+		// SearchRequest is sealed to the types above, so only pointers to them get here.
+		return nil, model.NewInvalidParameterError(fmt.Sprintf("search request must be passed by value, got %T", request))
+	}
+	if err != nil || resp.Result == nil || len(resp.Result.Data) == 0 {
+		return resp, err
+	}
+
+	documents := make(map[interface{}]model.MapStr, len(resp.Result.Data))
+	hits := resp.Result.Data
+	for start := 0; start < len(hits); start += fetchBatchSize {
+		end := start + fetchBatchSize
+		if end > len(hits) {
+			end = len(hits)
+		}
+		ids := make([]interface{}, 0, end-start)
+		for _, hit := range hits[start:end] {
+			ids = append(ids, hit.ID)
+		}
+		fetched, err := i.fetch(ctx, model.FetchDataInIndexRequest{IDs: ids, OutputFields: outputFields}, opts...)
+		if err != nil {
+			return resp, err
+		}
+		if fetched.Result == nil {
+			continue
+		}
+		for _, item := range fetched.Result.Items {
+			documents[model.IDKey(item.ID)] = item.Fields
+		}
+	}
+
+	ranked := make([]model.SearchItemResult, 0, len(hits))
+	for _, hit := range hits {
+		fields, ok := documents[model.IDKey(hit.ID)]
+		if !ok {
+			continue
+		}
+		hit.Fields = fields
+		ranked = append(ranked, hit)
+	}
+	resp.Result.Data = ranked
+	return resp, nil
+}
+
+const (
+	// sampleBatchLimit caps the documents requested per SearchByRandom call in Sample.
+	sampleBatchLimit = 100
+	// sampleMaxStaleRounds stops Sample after this many consecutive calls yield no new documents.
+	sampleMaxStaleRounds = 3
+)
+
+// Sample issues repeated SearchByRandom calls and returns up to n distinct documents. It returns fewer
+// than n when the index runs out of unseen documents.
+func (i *indexClient) Sample(ctx context.Context, n int, opts ...RequestOption) ([]model.SearchItemResult, error) {
+	if n <= 0 {
+		return nil, model.NewInvalidParameterError("sample size must be positive")
+	}
+
+	seen := make(map[interface{}]struct{}, n)
+	samples := make([]model.SearchItemResult, 0, n)
+	stale := 0
+	for len(samples) < n && stale < sampleMaxStaleRounds {
+		limit := n - len(samples)
+		if limit > sampleBatchLimit {
+			limit = sampleBatchLimit
+		}
+		resp, err := i.SearchByRandom(ctx, model.SearchByRandomRequest{
+			SearchBase: model.SearchBase{Limit: &limit},
+		}, opts...)
+		if err != nil {
+			return samples, err
+		}
+		if resp.Result == nil || len(resp.Result.Data) == 0 {
+			break
+		}
+
+		added := 0
+		for _, hit := range resp.Result.Data {
+			key := model.IDKey(hit.ID)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			samples = append(samples, hit)
+			added++
+			if len(samples) == n {
+				break
+			}
+		}
+		if added == 0 {
+			stale++
+		} else {
+			stale = 0
+		}
+	}
+	return samples, nil
+}
+
+// defaultExportPageSize is the page size used by Export when none is configured.
+const defaultExportPageSize = 100
+
+// Export writes every document of the index to w as newline-delimited JSON, one model.IndexDataItem
+// per line. Pages are read with SearchByScalar in ascending options.OrderField order, each starting at
+// the last exported value (gte) rather than at an offset, so the export is not bound by the server's
+// offset window. Documents sharing the boundary value are de-duplicated by id, and each page asks for
+// PageSize more rows than were already exported at that value, so ties never stall the export; a tie
+// group wider than the server's search limit surfaces as the server's limit error.
+func (i *indexClient) Export(ctx context.Context, w io.Writer, options model.ExportOptions, opts ...RequestOption) error {
+	if options.OrderField == "" {
+		return model.NewInvalidParameterError("export order field cannot be empty")
+	}
+	pageSize := options.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultExportPageSize
+	}
+	outputFields := options.OutputFields
+	stripOrderField := false
+	if len(outputFields) > 0 && !containsString(outputFields, options.OrderField) {
+		outputFields = append(append([]string(nil), outputFields...), options.OrderField)
+		stripOrderField = true
+	}
+
+	encoder := json.NewEncoder(w)
+	var cursor interface{}
+	// written holds the ids already exported whose order value equals cursor.
+	written := make(map[interface{}]bool)
+	for {
+		filter := options.Filter
+		if cursor != nil {
+			bound := model.MapStr{
+				model.FilterKeyOp:    model.OpRange,
+				model.FilterKeyField: options.OrderField,
+				model.CondGte:        cursor,
+			}
+			filter = bound
+			if len(options.Filter) > 0 {
+				filter = model.MapStr{model.FilterKeyOp: model.OpAnd, model.FilterKeyConds: []model.MapStr{options.Filter, bound}}
+			}
+		}
+		limit := pageSize + len(written)
+		resp, err := i.SearchByScalar(ctx, model.SearchByScalarRequest{
+			SearchBase: model.SearchBase{
+				RecallBase:   model.RecallBase{Filter: filter},
+				OutputFields: outputFields,
+				Limit:        &limit,
+			},
+			Field: &options.OrderField,
+			Order: model.ScalarOrderAsc,
+		}, opts...)
+		if err != nil {
+			return err
+		}
+		if resp.Result == nil || len(resp.Result.Data) == 0 {
+			return nil
+		}
+
+		hits := make([]model.SearchItemResult, 0, len(resp.Result.Data))
+		values := make([]interface{}, 0, len(resp.Result.Data))
+		for _, hit := range resp.Result.Data {
+			value, ok := hit.Fields[options.OrderField]
+			if !ok || value == nil {
+				return model.NewInvalidParameterError(fmt.Sprintf("document %v has no %s to page the export by", hit.ID, options.OrderField))
+			}
+			if cursor != nil && sameJSON(value, cursor) && written[model.IDKey(hit.ID)] {
+				continue
+			}
+			hits = append(hits, hit)
+			values = append(values, value)
+		}
+
+		fetchedItems := make(map[interface{}]model.IndexDataItem)
+		if options.IncludeVectors {
+			ids := make([]interface{}, 0, len(hits))
+			for _, hit := range hits {
+				ids = append(ids, hit.ID)
+			}
+			fetched, err := i.fetch(ctx, model.FetchDataInIndexRequest{IDs: ids, OutputFields: options.OutputFields}, opts...)
+			if err != nil {
+				return err
+			}
+			if fetched.Result != nil {
+				for _, item := range fetched.Result.Items {
+					fetchedItems[model.IDKey(item.ID)] = item
+				}
+			}
+		}
+
+		for idx, hit := range hits {
+			fields := hit.Fields
+			if stripOrderField {
+				fields = make(model.MapStr, len(hit.Fields))
+				for k, v := range hit.Fields {
+					if k != options.OrderField {
+						fields[k] = v
+					}
+				}
+			}
+			line := model.IndexDataItem{
+				DataItem: model.DataItem{ID: hit.ID, Fields: fields},
+			}
+			if item, ok := fetchedItems[model.IDKey(hit.ID)]; ok {
+				line.DenseVector = item.DenseVector
+				line.DenseDim = len(item.DenseVector)
+				line.DenseVectors = item.DenseVectors
+			}
+			if err := encoder.Encode(line); err != nil {
+				return model.NewErrorWithCause(model.ErrCodeUnknown, "failed to write export line", err, http.StatusInternalServerError)
+			}
+			if cursor == nil || !sameJSON(values[idx], cursor) {
+				cursor = values[idx]
+				written = make(map[interface{}]bool)
+			}
+			written[model.IDKey(hit.ID)] = true
+		}
+
+		if len(resp.Result.Data) < limit {
+			return nil
+		}
+	}
+}
+
+// containsString reports whether values holds s.
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
 
 func (i *indexClient) Aggregate(ctx context.Context, request model.AggRequest, opts ...RequestOption) (*model.AggResponse, error) {
+	if err := i.indexBase.Validate(); err != nil {
+		return nil, err
+	}
+	i.applyDefaultPartition(&request.Partition)
 	response := &model.AggResponse{}
 	req := struct {
 		model.IndexLocator
@@ -116,9 +478,102 @@ func (i *indexClient) Aggregate(ctx context.Context, request model.AggRequest, o
 		AggRequest:   request,
 	}
 	err := i.transport.doRequest(ctx, http.MethodPost, "/api/vikingdb/data/agg", req, response, opts...)
+	if err == nil && response.Result != nil && (response.Result.Op == model.AggOpCount || request.Op == model.AggOpCount) {
+		response.Result.Count = aggCount(response.Result.Agg)
+	}
 	return response, err
 }
 
+// AggregateConcurrent is a convenience for running several aggregations over the same documents.
+// The agg endpoint takes one op per request, so it still sends one request per op; they are issued
+// concurrently, at most request.Concurrency at a time, and collected by AggSpec.Key. It fails with the
+// first error in Ops order if any op fails.
+func (i *indexClient) AggregateConcurrent(ctx context.Context, request model.MultiAggRequest, opts ...RequestOption) (*model.MultiAggResponse, error) {
+	if err := i.indexBase.Validate(); err != nil {
+		return nil, err
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	responses := make([]*model.AggResponse, len(request.Ops))
+	errs := make([]error, len(request.Ops))
+	fanOut(len(request.Ops), request.Concurrency, func(idx int) {
+		spec := request.Ops[idx]
+		agg := model.AggRequest{RecallBase: request.RecallBase, Op: spec.Op, Cond: request.Cond}
+		if spec.Field != "" {
+			field := spec.Field
+			agg.Field = &field
+		}
+		responses[idx], errs[idx] = i.Aggregate(ctx, agg, opts...)
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	merged := &model.MultiAggResponse{
+		Results:    make(map[string]*model.AggResult, len(request.Ops)),
+		RequestIDs: make([]string, len(request.Ops)),
+	}
+	for idx, spec := range request.Ops {
+		merged.Results[spec.Key()] = responses[idx].Result
+		merged.RequestIDs[idx] = responses[idx].RequestID
+	}
+	return merged, nil
+}
+
+// aggCount resolves the total of a count aggregation from the raw agg map.
+func aggCount(agg model.MapStr) int64 {
+	if total, ok := toInt64(agg[model.AggTotalKey]); ok {
+		return total
+	}
+	var sum int64
+	for _, v := range agg {
+		if n, ok := toInt64(v); ok {
+			sum += n
+		}
+	}
+	return sum
+}
+
+// toInt64 converts decoded JSON numbers into int64.
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return i, true
+		}
+		if f, err := n.Float64(); err == nil {
+			return int64(f), true
+		}
+	case float64:
+		return int64(n), true
+	case float32:
+		return int64(n), true
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	}
+	return 0, false
+}
+
+// Stats counts the documents the index serves with a count aggregation on /api/vikingdb/data/agg.
+// The data API reports no build status or update time, so only the document count is returned.
+func (i *indexClient) Stats(ctx context.Context, opts ...RequestOption) (*model.IndexStats, error) {
+	response, err := i.Aggregate(ctx, model.AggRequest{Op: model.AggOpCount}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if response.Result == nil {
+		return nil, model.NewError(model.ErrCodeUnknown, "count aggregation returned no result")
+	}
+	return &model.IndexStats{RowCount: response.Result.Count}, nil
+}
+
 func (i *indexClient) CollectionName() string {
 	return i.indexBase.CollectionName
 }
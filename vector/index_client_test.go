@@ -0,0 +1,313 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+type fakeDoc struct {
+	id     string
+	rank   float64
+	name   string
+	vector []float32
+}
+
+// fakeIndexServer serves scalar search, fetch_in_index and upsert over docs. Scalar search orders by
+// the "rank" field only, returning ties in reverse id order to show Export does not rely on a stable
+// tie order, and supports a gte range on rank.
+type fakeIndexServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	docs     []fakeDoc
+	upserted []model.MapStr
+}
+
+func newFakeIndexServer(t *testing.T, docs []fakeDoc) *fakeIndexServer {
+	t.Helper()
+	f := &fakeIndexServer{docs: docs}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		var result interface{}
+		switch r.URL.Path {
+		case "/api/vikingdb/data/search/scalar":
+			result = f.scalar(body)
+		case "/api/vikingdb/data/fetch_in_index":
+			result = f.fetch(body)
+		case "/api/vikingdb/data/upsert":
+			f.mu.Lock()
+			for _, record := range body["data"].([]interface{}) {
+				f.upserted = append(f.upserted, record.(map[string]interface{}))
+			}
+			f.mu.Unlock()
+			result = map[string]interface{}{}
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"request_id": "req", "result": result})
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeIndexServer) scalar(body map[string]interface{}) interface{} {
+	lower, hasLower := 0.0, false
+	if filter, ok := body["filter"].(map[string]interface{}); ok {
+		lower, hasLower = filter[model.CondGte].(float64)
+	}
+	docs := make([]fakeDoc, 0, len(f.docs))
+	for _, doc := range f.docs {
+		if !hasLower || doc.rank >= lower {
+			docs = append(docs, doc)
+		}
+	}
+	sort.SliceStable(docs, func(a, b int) bool {
+		if docs[a].rank != docs[b].rank {
+			return docs[a].rank < docs[b].rank
+		}
+		return docs[a].id > docs[b].id
+	})
+	if limit := int(body["limit"].(float64)); len(docs) > limit {
+		docs = docs[:limit]
+	}
+	data := make([]map[string]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		data = append(data, map[string]interface{}{"id": doc.id, "fields": map[string]interface{}{"rank": doc.rank, "name": doc.name}})
+	}
+	return map[string]interface{}{"data": data}
+}
+
+func (f *fakeIndexServer) fetch(body map[string]interface{}) interface{} {
+	items := make([]map[string]interface{}, 0)
+	for _, id := range body["ids"].([]interface{}) {
+		for _, doc := range f.docs {
+			if doc.id == id {
+				items = append(items, map[string]interface{}{"id": doc.id, "dense_vector": doc.vector})
+			}
+		}
+	}
+	return map[string]interface{}{"fetch": items}
+}
+
+func TestExportImportRoundTripAcrossTiedPages(t *testing.T) {
+	// Four documents share rank 2, more than one PageSize-3 page holds, and the fake returns ties in
+	// reverse id order.
+	docs := []fakeDoc{
+		{id: "a", rank: 1, name: "alpha", vector: []float32{1, 0}},
+		{id: "b", rank: 2, name: "bravo", vector: []float32{0, 1}},
+		{id: "c", rank: 2, name: "charlie", vector: []float32{1, 1}},
+		{id: "d", rank: 2, name: "delta", vector: []float32{0.5, 0}},
+		{id: "e", rank: 2, name: "echo", vector: []float32{0, 0.5}},
+		{id: "f", rank: 3, name: "foxtrot", vector: []float32{0.25, 0.25}},
+	}
+	server := newFakeIndexServer(t, docs)
+	client := newTestClient(t, server.URL)
+
+	var buf bytes.Buffer
+	err := client.Index(model.NewIndexLocator("collection", "index")).Export(context.Background(), &buf, model.ExportOptions{
+		OrderField:     "rank",
+		OutputFields:   []string{"name"},
+		IncludeVectors: true,
+		PageSize:       3,
+	})
+	require.NoError(t, err)
+
+	result, err := client.Collection(model.CollectionLocator{CollectionName: "collection"}).Import(context.Background(), &buf, model.ImportOptions{
+		PrimaryKey:  "pk",
+		VectorField: "vector",
+		BatchSize:   4,
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(docs), result.Imported)
+
+	got := make(map[string]model.MapStr, len(server.upserted))
+	for _, record := range server.upserted {
+		require.NotContains(t, record, "rank", "order field was not requested and must not be exported")
+		got[record["pk"].(string)] = record
+	}
+	require.Len(t, got, len(docs), "every document exported exactly once")
+	for _, doc := range docs {
+		record := got[doc.id]
+		require.Equal(t, doc.name, record["name"])
+		vector := make([]float32, 0, len(doc.vector))
+		for _, v := range record["vector"].([]interface{}) {
+			vector = append(vector, float32(v.(float64)))
+		}
+		require.Equal(t, doc.vector, vector)
+	}
+}
+
+func TestExportTieGroupWiderThanPage(t *testing.T) {
+	docs := []fakeDoc{
+		{id: "a", rank: 1, name: "alpha"},
+		{id: "b", rank: 1, name: "bravo"},
+		{id: "c", rank: 1, name: "charlie"},
+		{id: "d", rank: 2, name: "delta"},
+	}
+	server := newFakeIndexServer(t, docs)
+
+	var buf bytes.Buffer
+	err := newTestIndexClient(t, server.URL).Export(context.Background(), &buf, model.ExportOptions{OrderField: "rank", PageSize: 2})
+	require.NoError(t, err)
+
+	var ids []string
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var item model.IndexDataItem
+		require.NoError(t, decoder.Decode(&item))
+		require.Contains(t, item.Fields, "rank", "all fields are exported when OutputFields is empty")
+		ids = append(ids, item.ID.(string))
+	}
+	sort.Strings(ids)
+	require.Equal(t, []string{"a", "b", "c", "d"}, ids)
+}
+
+func TestSearchWindowErrorsAreClassifiedByCode(t *testing.T) {
+	cases := []struct {
+		name       string
+		reply      scriptedReply
+		wantCode   model.ErrorCode
+		wantWindow bool
+	}{
+		{"window code", scriptedReply{http.StatusUnprocessableEntity, `{"code":"SearchWindowExceeded","message":"offset+limit > 10000","request_id":"req-w"}`}, model.ErrCodeInvalidParameter, true},
+		{"window-worded message", scriptedReply{http.StatusBadRequest, `{"code":"InvalidFilter","message":"offset field exceeds range","request_id":"req-w"}`}, "InvalidFilter", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newScriptedServer(t, tc.reply)
+			_, err := newTestIndexClient(t, server.URL).SearchByRandom(context.Background(), randomSearch())
+
+			var sdkErr *model.Error
+			require.ErrorAs(t, err, &sdkErr)
+			require.Equal(t, tc.wantCode, sdkErr.Code)
+			require.Equal(t, tc.reply.status, sdkErr.StatusCode, "the server status is kept")
+			require.Equal(t, "req-w", sdkErr.RequestID)
+			require.Equal(t, tc.wantWindow, strings.Contains(sdkErr.Message, "search window"))
+		})
+	}
+}
+
+func TestSearchAndFetchReplacesFieldsInRankOrder(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, `{"request_id":"req-search","result":{"data":[{"id":2,"score":0.9,"fields":{"title":"stale"}},{"id":1,"score":0.5},{"id":3,"score":0.1}]}}`},
+		scriptedReply{http.StatusOK, `{"request_id":"req-fetch","result":{"fetch":[{"id":1,"fields":{"title":"one"}},{"id":2,"fields":{"title":"two"}}],"ids_not_exist":[3]}}`},
+	)
+	recorder, record := recordBodies(t)
+	index := newTestIndexClient(t, server.URL, record)
+
+	resp, err := index.SearchAndFetch(context.Background(), randomSearch(), []string{"title"})
+	require.NoError(t, err)
+	require.Len(t, resp.Result.Data, 2, "hit 3 vanished before the fetch")
+	require.Equal(t, model.MapStr{"title": "two"}, resp.Result.Data[0].Fields)
+	require.Equal(t, float32(0.9), resp.Result.Data[0].Score)
+	require.Equal(t, model.MapStr{"title": "one"}, resp.Result.Data[1].Fields)
+	require.Contains(t, recorder.Last(), `"output_fields":["title"]`)
+
+	_, err = index.SearchAndFetch(context.Background(), &model.SearchByRandomRequest{}, nil)
+	require.Error(t, err)
+	require.Equal(t, 2, server.Calls())
+}
+
+func TestOptionalSearchFieldsWireFormat(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, okSearchBody})
+	recorder, record := recordBodies(t)
+	index := newTestIndexClient(t, server.URL, record)
+
+	_, err := index.SearchByVector(context.Background(), NewSearch().Limit(1).Vector([]float64{1}).Metric(model.MetricCosine).Build())
+	require.NoError(t, err)
+	require.Contains(t, recorder.Last(), `"metric":"cosine"`)
+	_, err = index.SearchByVector(context.Background(), NewSearch().Limit(1).Vector([]float64{1}).Build())
+	require.NoError(t, err)
+	require.NotContains(t, recorder.Last(), `"metric"`)
+
+	keywords := model.SearchByKeywordsRequest{
+		SearchBase:  NewSearch().Limit(1).Base(),
+		Keywords:    []string{"dragon"},
+		FieldBoosts: map[string]float64{"title": 2},
+	}
+	_, err = index.SearchByKeywords(context.Background(), keywords)
+	require.NoError(t, err)
+	require.Contains(t, recorder.Last(), `"field_boosts":{"title":2}`)
+
+	field := "score"
+	_, err = index.Aggregate(context.Background(), model.AggRequest{Op: "min", Field: &field, OutputFields: []string{"title"}})
+	require.NoError(t, err)
+	require.Contains(t, recorder.Last(), `"output_fields":["title"]`)
+
+	keywords.FieldBoosts = map[string]float64{"title": 0}
+	_, err = index.SearchByKeywords(context.Background(), keywords)
+	require.Error(t, err)
+	_, err = index.SearchByVector(context.Background(), NewSearch().Limit(1).Vector([]float64{1}).Metric("hamming").Build())
+	require.Error(t, err)
+	require.Len(t, recorder.Bodies(), 4, "invalid metric and boosts are rejected before sending")
+}
+
+func TestOptionalResponseFieldsDecode(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, `{"result":{"data":[{"id":1,"ann_score":0.8,"partition":"tenant-a"},{"id":2,"partition":7},{"id":3}]}}`},
+		scriptedReply{http.StatusOK, `{"result":{"agg":{"red":2},"docs":{"red":[{"id":1,"fields":{"title":"apple"}}]}}}`},
+	)
+	index := newTestIndexClient(t, server.URL)
+
+	resp, err := index.SearchByRandom(context.Background(), randomSearch())
+	require.NoError(t, err)
+	require.Equal(t, model.HitPartition("tenant-a"), resp.Result.Data[0].Partition)
+	require.Equal(t, float32(0.8), resp.Result.Data[0].ANNScore)
+	require.Equal(t, model.HitPartition("7"), resp.Result.Data[1].Partition)
+	require.Empty(t, resp.Result.Data[2].Partition)
+
+	field := "color"
+	agg, err := index.Aggregate(context.Background(), model.AggRequest{Op: "group_by", Field: &field})
+	require.NoError(t, err)
+	require.Equal(t, model.MapStr{"title": "apple"}, agg.Result.Docs["red"][0].Fields)
+}
+
+func TestStatsCountsWithAggregation(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, `{"result":{"op":"count","agg":{"__TOTAL__":10}}}`})
+	recorder, record := recordBodies(t)
+
+	stats, err := newTestIndexClient(t, server.URL, record).Stats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"/api/vikingdb/data/agg"}, recorder.Paths())
+	require.JSONEq(t, `{"collection_name":"collection","index_name":"index","op":"count"}`, recorder.Last())
+	require.Equal(t, &model.IndexStats{RowCount: 10}, stats)
+}
+
+func TestSampleCollectsDistinctDocuments(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, `{"result":{"data":[{"id":1},{"id":2},{"id":1}]}}`},
+		scriptedReply{http.StatusOK, `{"result":{"data":[{"id":2},{"id":3}]}}`},
+		scriptedReply{http.StatusOK, `{"result":{"data":[{"id":3},{"id":1}]}}`},
+	)
+	recorder, record := recordBodies(t)
+	index := newTestIndexClient(t, server.URL, record)
+
+	samples, err := index.Sample(context.Background(), 4)
+	require.NoError(t, err)
+	ids := make([]interface{}, len(samples))
+	for i, hit := range samples {
+		ids[i] = model.IDKey(hit.ID)
+	}
+	require.Equal(t, []interface{}{model.IDKey(1), model.IDKey(2), model.IDKey(3)}, ids, "only distinct documents, in arrival order")
+	bodies := recorder.Bodies()
+	require.Contains(t, bodies[0], `"limit":4`)
+	require.Contains(t, bodies[1], `"limit":2`, "later calls ask only for the missing documents")
+	require.Equal(t, 2+3, server.Calls(), "stops after three calls in a row add nothing")
+
+	_, err = index.Sample(context.Background(), 0)
+	require.Error(t, err)
+}
@@ -5,6 +5,7 @@ package vector
 
 import (
 	"context"
+	"io"
 
 	"github.com/volcengine/vikingdb-go-sdk/vector/model"
 )
@@ -12,9 +13,18 @@ import (
 // CollectionClient provides collection-scoped data operations.
 type CollectionClient interface {
 	Upsert(ctx context.Context, request model.UpsertDataRequest, opts ...RequestOption) (*model.UpsertDataResponse, error)
+	UpsertBatchConcurrent(ctx context.Context, data []model.MapStr, options model.ConcurrentUpsertOptions, opts ...RequestOption) (*model.ConcurrentUpsertResult, error)
+	UpsertWithEmbedding(ctx context.Context, request model.EmbedAndUpsertRequest, opts ...RequestOption) (*model.EmbedAndUpsertResponse, error)
 	Update(ctx context.Context, request model.UpdateDataRequest, opts ...RequestOption) (*model.UpdateDataResponse, error)
+	UpdateChanged(ctx context.Context, id interface{}, newFields model.MapStr, opts ...RequestOption) (*model.UpdateDataResponse, error)
+	UpsertAndFetchVectors(ctx context.Context, indexName string, request model.UpsertDataRequest, opts ...RequestOption) (*model.UpsertDataResponse, *model.FetchDataInIndexResponse, error)
 	Delete(ctx context.Context, request model.DeleteDataRequest, opts ...RequestOption) (*model.DeleteDataResponse, error)
 	Fetch(ctx context.Context, request model.FetchDataInCollectionRequest, opts ...RequestOption) (*model.FetchDataInCollectionResponse, error)
+	Describe(ctx context.Context, opts ...RequestOption) (*model.DescribeCollectionResponse, error)
+	Fields(ctx context.Context, opts ...RequestOption) ([]model.FieldSchema, error)
+	PrimaryKey(ctx context.Context, opts ...RequestOption) (string, error)
+	ListIndexes(ctx context.Context, request model.ListIndexesRequest, opts ...RequestOption) (*model.ListIndexesResponse, error)
+	Import(ctx context.Context, r io.Reader, options model.ImportOptions, opts ...RequestOption) (*model.ImportResult, error)
 
 	CollectionName() string
 	ResourceID() string
@@ -31,6 +41,11 @@ type IndexClient interface {
 	SearchByKeywords(ctx context.Context, request model.SearchByKeywordsRequest, opts ...RequestOption) (*model.SearchResponse, error)
 	SearchByRandom(ctx context.Context, request model.SearchByRandomRequest, opts ...RequestOption) (*model.SearchResponse, error)
 	Aggregate(ctx context.Context, request model.AggRequest, opts ...RequestOption) (*model.AggResponse, error)
+	AggregateConcurrent(ctx context.Context, request model.MultiAggRequest, opts ...RequestOption) (*model.MultiAggResponse, error)
+	SearchAndFetch(ctx context.Context, request model.SearchRequest, outputFields []string, opts ...RequestOption) (*model.SearchResponse, error)
+	Sample(ctx context.Context, n int, opts ...RequestOption) ([]model.SearchItemResult, error)
+	Export(ctx context.Context, w io.Writer, options model.ExportOptions, opts ...RequestOption) error
+	Stats(ctx context.Context, opts ...RequestOption) (*model.IndexStats, error)
 
 	CollectionName() string
 	IndexName() string
@@ -41,6 +56,7 @@ type IndexClient interface {
 // EmbeddingClient provides embedding operations.
 type EmbeddingClient interface {
 	Embedding(ctx context.Context, request model.EmbeddingRequest, opts ...RequestOption) (*model.EmbeddingResponse, error)
+	EmbeddingCached(ctx context.Context, request model.EmbeddingRequest, opts ...RequestOption) (*model.EmbeddingResponse, error)
 }
 
 // RerankClient provides embedding operations.
@@ -3,6 +3,12 @@
 
 package model
 
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
 // DataItem represents a document stored in the collection.
 type DataItem struct {
 	ID     interface{} `json:"id"`
@@ -11,9 +17,31 @@ type DataItem struct {
 
 // WriteDataBase holds common fields for data writes.
 type WriteDataBase struct {
-	Data                []MapStr `json:"data"`
-	TTL                 *int32   `json:"ttl,omitempty"`
-	IgnoreUnknownFields bool     `json:"ignore_unknown_fields,omitempty"`
+	Data []MapStr `json:"data"`
+	// TTL is the document lifetime in seconds; the server expires the documents afterwards. Use WithTTL
+	// to build it from a time.Duration. Nil keeps the documents until deleted.
+	TTL                 *int32 `json:"ttl,omitempty"`
+	IgnoreUnknownFields bool   `json:"ignore_unknown_fields,omitempty"`
+	// BatchSize splits Data into chunks of this many records, sent as consecutive requests. Collections
+	// with server-side vectorization accept a single record per write, so set it to 1 for them.
+	// Zero sends all records in one request.
+	BatchSize int `json:"-"`
+}
+
+// WithTTL converts d into the whole seconds expected by WriteDataBase.TTL, rounding up partial seconds.
+func WithTTL(d time.Duration) (*int32, error) {
+	if d < 0 {
+		return nil, NewInvalidParameterError("ttl cannot be negative")
+	}
+	seconds := int64(d / time.Second)
+	if d%time.Second != 0 {
+		seconds++
+	}
+	if seconds > math.MaxInt32 {
+		return nil, NewInvalidParameterError(fmt.Sprintf("ttl cannot exceed %d seconds", math.MaxInt32))
+	}
+	ttl := int32(seconds)
+	return &ttl, nil
 }
 
 // UpsertDataRequest creates or updates documents within a collection.
@@ -30,6 +58,74 @@ type UpsertDataResponse struct {
 
 type UpsertDataResult struct {
 	TokenUsage interface{} `json:"token_usage,omitempty"`
+	// Records lists the per-record outcome in request order. It is empty when the server does not
+	// report record status.
+	Records []UpsertRecordStatus `json:"records,omitempty"`
+
+	// Accepted is set when the server queued the write (Async or HTTP 202) instead of applying it
+	// before responding; the documents become visible once the task completes.
+	Accepted bool `json:"-"`
+	// TaskID identifies the queued write when the server reports one. It stays empty for upserts
+	// split by BatchSize, which queue one task per chunk.
+	TaskID string `json:"task_id,omitempty"`
+}
+
+// UpsertStatus tells whether an upsert inserted a new document or replaced an existing one.
+type UpsertStatus string
+
+const (
+	UpsertStatusCreated UpsertStatus = "created"
+	UpsertStatusUpdated UpsertStatus = "updated"
+)
+
+// UpsertRecordStatus is the outcome of a single upserted record.
+type UpsertRecordStatus struct {
+	ID     interface{}  `json:"id"`
+	Status UpsertStatus `json:"status"`
+}
+
+// CreatedCount returns how many records were inserted as new documents.
+func (r *UpsertDataResult) CreatedCount() int {
+	return r.countStatus(UpsertStatusCreated)
+}
+
+// UpdatedCount returns how many records replaced existing documents.
+func (r *UpsertDataResult) UpdatedCount() int {
+	return r.countStatus(UpsertStatusUpdated)
+}
+
+func (r *UpsertDataResult) countStatus(status UpsertStatus) int {
+	if r == nil {
+		return 0
+	}
+	count := 0
+	for _, record := range r.Records {
+		if record.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// EmbedAndUpsertRequest embeds the text of each record and upserts the records with the resulting vectors.
+// Unlike plain writes, BatchSize defaults to 1 so the request works against vectorize collections.
+type EmbedAndUpsertRequest struct {
+	WriteDataBase
+	// TextField names the record field whose string value is sent to the embedding model.
+	TextField string
+	// VectorField receives the dense vector generated for each record.
+	VectorField string
+	// SparseVectorField optionally receives the sparse vector when SparseModel is set.
+	SparseVectorField string
+	DenseModel        *EmbeddingModelOpt
+	SparseModel       *EmbeddingModelOpt
+	Async             bool
+}
+
+// EmbedAndUpsertResponse carries the embedding response and every upsert response issued.
+type EmbedAndUpsertResponse struct {
+	Embedding *EmbeddingResponse
+	Upserts   []*UpsertDataResponse
 }
 
 // UpdateDataRequest updates existing documents.
@@ -48,6 +144,7 @@ type UpdateDataResult struct {
 
 // DeleteDataRequest removes documents by primary key.
 type DeleteDataRequest struct {
+	// IDs are normalized by CanonicalIDs before sending; an id it rejects fails the call unsent.
 	IDs    []interface{} `json:"ids"`
 	DelAll bool          `json:"del_all,omitempty"`
 }
@@ -58,6 +155,7 @@ type DeleteDataResponse struct {
 
 // FetchDataInCollectionRequest fetches documents by primary key from a collection.
 type FetchDataInCollectionRequest struct {
+	// IDs are normalized by CanonicalIDs before sending; an id it rejects fails the call unsent.
 	IDs []interface{} `json:"ids"`
 }
 
@@ -71,3 +169,66 @@ type FetchDataInCollectionResult struct {
 	Items       []DataItem    `json:"fetch,omitempty"`
 	NotFoundIDs []interface{} `json:"ids_not_exist,omitempty"`
 }
+
+// Item returns the fetched document whose primary key equals id under ParseID canonicalization.
+func (r *FetchDataInCollectionResult) Item(id interface{}) (DataItem, bool) {
+	want, err := ParseID(id)
+	if r == nil || err != nil {
+		return DataItem{}, false
+	}
+	for _, item := range r.Items {
+		if got, err := ParseID(item.ID); err == nil && got == want {
+			return item, true
+		}
+	}
+	return DataItem{}, false
+}
+
+// ImportOptions controls CollectionClient.Import of NDJSON produced by IndexClient.Export.
+type ImportOptions struct {
+	// PrimaryKey names the field that receives each line's id. Leave empty for auto-id collections.
+	PrimaryKey string
+	// VectorField names the field that receives each line's dense_vector, when present. Named
+	// dense_vectors of multi-vector collections are written to their own fields.
+	VectorField string
+	// BatchSize is the number of records per upsert, 1 by default as vectorize collections require.
+	BatchSize int
+}
+
+// ImportResult reports how many records were written by CollectionClient.Import.
+type ImportResult struct {
+	Imported int
+}
+
+// ConcurrentUpsertOptions controls CollectionClient.UpsertBatchConcurrent.
+type ConcurrentUpsertOptions struct {
+	// BatchSize is the number of records per upsert, 1 by default as vectorize collections require.
+	BatchSize int
+	// Concurrency caps the chunks in flight, 4 by default.
+	Concurrency int
+	// StopOnError stops launching new chunks after the first failure.
+	StopOnError         bool
+	TTL                 *int32
+	IgnoreUnknownFields bool
+}
+
+// ChunkRange addresses the records data[Start:End] of a concurrent upsert.
+type ChunkRange struct {
+	Start int
+	End   int
+}
+
+// ChunkFailure is a chunk whose upsert returned Err. A chunk that failed because its request was
+// cancelled mid-flight may still have been applied by the server.
+type ChunkFailure struct {
+	ChunkRange
+	Err error
+}
+
+// ConcurrentUpsertResult reports the outcome of every chunk, each list ordered by Start.
+type ConcurrentUpsertResult struct {
+	Committed []ChunkRange
+	Failed    []ChunkFailure
+	// Skipped lists chunks never sent because the context was cancelled or StopOnError triggered.
+	Skipped []ChunkRange
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+)
+
+// FieldType names the storage type of a collection field.
+type FieldType string
+
+const (
+	FieldTypeInt64        FieldType = "int64"
+	FieldTypeFloat32      FieldType = "float32"
+	FieldTypeString       FieldType = "string"
+	FieldTypeBool         FieldType = "bool"
+	FieldTypeListString   FieldType = "list<string>"
+	FieldTypeListInt64    FieldType = "list<int64>"
+	FieldTypeVector       FieldType = "vector"
+	FieldTypeSparseVector FieldType = "sparse_vector"
+	FieldTypeText         FieldType = "text"
+	FieldTypeImage        FieldType = "image"
+	FieldTypeVideo        FieldType = "video"
+	FieldTypeDateTime     FieldType = "date_time"
+	FieldTypeGeoPoint     FieldType = "geo_point"
+)
+
+// FieldSchema describes a single field of a collection.
+type FieldSchema struct {
+	Name         string      `json:"field_name"`
+	Type         FieldType   `json:"field_type"`
+	Dim          int         `json:"dim,omitempty"`
+	IsPrimary    bool        `json:"is_primary_key,omitempty"`
+	DefaultValue interface{} `json:"default_val,omitempty"`
+}
+
+// CollectionInfo carries the collection metadata returned by Describe.
+type CollectionInfo struct {
+	CollectionName string        `json:"collection_name"`
+	ProjectName    string        `json:"project_name,omitempty"`
+	ResourceID     string        `json:"resource_id,omitempty"`
+	Description    string        `json:"description,omitempty"`
+	PrimaryKey     string        `json:"primary_key,omitempty"`
+	Fields         []FieldSchema `json:"fields,omitempty"`
+}
+
+// DescribeCollectionResponse mirrors DataApiResponse<CollectionInfo>.
+type DescribeCollectionResponse struct {
+	CommonResponse
+	Result *CollectionInfo `json:"result,omitempty"`
+}
+
+// AutoIDField is the primary key field of collections whose keys are generated by the server.
+const AutoIDField = "__AUTO_ID__"
+
+// ValidateRecord checks record against the collection schema: the primary key must be present unless
+// the collection uses AutoIDField, and every value of a known field must match its field type, with
+// vectors holding exactly Dim components. Fields missing from the schema are not checked; the server
+// decides whether to accept them. Nil values are skipped.
+func ValidateRecord(record MapStr, schema []FieldSchema) error {
+	for _, field := range schema {
+		value, ok := record[field.Name]
+		if !ok {
+			if field.IsPrimary && field.Name != AutoIDField {
+				return NewInvalidParameterError(fmt.Sprintf("primary key field %s is missing", field.Name))
+			}
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if err := checkFieldValue(field, value); err != nil {
+			return NewErrorWithCause(ErrCodeInvalidParameter, fmt.Sprintf("field %s does not match its %s schema", field.Name, field.Type), err, http.StatusBadRequest)
+		}
+	}
+	return nil
+}
+
+func checkFieldValue(field FieldSchema, value interface{}) error {
+	switch field.Type {
+	case FieldTypeInt64:
+		if !isInteger(value) {
+			return fmt.Errorf("expected an integer, got %T", value)
+		}
+	case FieldTypeFloat32:
+		if !isNumber(value) {
+			return fmt.Errorf("expected a number, got %T", value)
+		}
+	case FieldTypeString, FieldTypeText, FieldTypeImage, FieldTypeVideo, FieldTypeDateTime:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("expected a string, got %T", value)
+		}
+	case FieldTypeBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected a bool, got %T", value)
+		}
+	case FieldTypeListString:
+		return checkList(value, func(item interface{}) bool {
+			_, ok := item.(string)
+			return ok
+		}, "string")
+	case FieldTypeListInt64:
+		return checkList(value, isInteger, "integer")
+	case FieldTypeVector:
+		length, err := listLength(value, isNumber, "number")
+		if err != nil {
+			return err
+		}
+		if field.Dim > 0 && length != field.Dim {
+			return fmt.Errorf("expected %d dimensions, got %d", field.Dim, length)
+		}
+	case FieldTypeSparseVector:
+		switch v := value.(type) {
+		case SparseVector, map[string]float64, map[string]float32:
+		case MapStr:
+			for key, weight := range v {
+				if !isNumber(weight) {
+					return fmt.Errorf("weight of %q is %T, not a number", key, weight)
+				}
+			}
+		case map[string]interface{}:
+			for key, weight := range v {
+				if !isNumber(weight) {
+					return fmt.Errorf("weight of %q is %T, not a number", key, weight)
+				}
+			}
+		default:
+			return fmt.Errorf("expected a term to weight map, got %T", value)
+		}
+	}
+	return nil
+}
+
+func checkList(value interface{}, valid func(interface{}) bool, kind string) error {
+	_, err := listLength(value, valid, kind)
+	return err
+}
+
+// listLength returns the length of a slice whose items all satisfy valid.
+func listLength(value interface{}, valid func(interface{}) bool, kind string) (int, error) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return 0, fmt.Errorf("expected a list, got %T", value)
+	}
+	for idx := 0; idx < rv.Len(); idx++ {
+		if item := rv.Index(idx).Interface(); !valid(item) {
+			return 0, fmt.Errorf("item %d is %T, not a %s", idx, item, kind)
+		}
+	}
+	return rv.Len(), nil
+}
+
+func isNumber(value interface{}) bool {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32:
+		return true
+	case float64:
+		return !math.IsNaN(v) && !math.IsInf(v, 0)
+	case json.Number:
+		_, err := v.Float64()
+		return err == nil
+	}
+	return false
+}
+
+func isInteger(value interface{}) bool {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	case float32:
+		return float64(v) == math.Trunc(float64(v))
+	case float64:
+		return v == math.Trunc(v) && !math.IsInf(v, 0)
+	case json.Number:
+		_, err := v.Int64()
+		return err == nil
+	}
+	return false
+}
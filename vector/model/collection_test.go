@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRecord(t *testing.T) {
+	schema := []FieldSchema{
+		{Name: "id", Type: FieldTypeInt64, IsPrimary: true},
+		{Name: "title", Type: FieldTypeString},
+		{Name: "tags", Type: FieldTypeListString},
+		{Name: "vector", Type: FieldTypeVector, Dim: 3},
+	}
+
+	require.NoError(t, ValidateRecord(MapStr{
+		"id":     json.Number("7"),
+		"title":  "ok",
+		"tags":   []interface{}{"a", "b"},
+		"vector": []float64{0.1, 0.2, 0.3},
+		"extra":  true,
+	}, schema))
+
+	require.Error(t, ValidateRecord(MapStr{"title": "no id"}, schema), "primary key is required")
+	require.Error(t, ValidateRecord(MapStr{"id": 1.5}, schema), "int64 rejects fractions")
+	require.Error(t, ValidateRecord(MapStr{"id": 1, "title": 3}, schema))
+	require.Error(t, ValidateRecord(MapStr{"id": 1, "tags": []interface{}{"a", 2}}, schema))
+	require.Error(t, ValidateRecord(MapStr{"id": 1, "vector": []float32{0.1}}, schema), "dimension mismatch")
+
+	autoID := []FieldSchema{{Name: AutoIDField, Type: FieldTypeInt64, IsPrimary: true}}
+	require.NoError(t, ValidateRecord(MapStr{}, autoID), "auto-id keys are generated by the server")
+}
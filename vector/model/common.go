@@ -3,19 +3,69 @@
 
 package model
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // CommonResponse represents the shared response envelope returned by VikingDB APIs.
 type CommonResponse struct {
 	API       string `json:"api,omitempty"`
 	Message   string `json:"message,omitempty"`
 	Code      string `json:"code,omitempty"`
 	RequestID string `json:"request_id,omitempty"`
+
+	// HTTPStatus is the HTTP status code of the response, recorded by the SDK.
+	HTTPStatus int `json:"-"`
+	// Extra holds top-level response keys the SDK does not model. It is only populated when the
+	// client is created with WithCaptureUnknownFields.
+	Extra MapStr `json:"-"`
+}
+
+// SetHTTPStatus records the HTTP status code; the SDK calls it after decoding a successful response.
+func (r *CommonResponse) SetHTTPStatus(code int) {
+	r.HTTPStatus = code
+}
+
+// SetExtra records unmodeled top-level response keys; the SDK calls it after decoding.
+func (r *CommonResponse) SetExtra(extra MapStr) {
+	r.Extra = extra
 }
 
-// CollectionLocator carries general collection level identifiers.
+// CollectionLocator carries general collection level identifiers. A collection is addressed either
+// by CollectionName (optionally scoped by ProjectName) or by ResourceID; exactly one must be set.
 type CollectionLocator struct {
-	CollectionName string `json:"collection_name"`
+	CollectionName string `json:"collection_name,omitempty"`
 	ProjectName    string `json:"project_name,omitempty"`
-	ResourceID     string `json:"resource_id"`
+	ResourceID     string `json:"resource_id,omitempty"`
+}
+
+// Validate checks that exactly one of CollectionName and ResourceID is set.
+func (l CollectionLocator) Validate() error {
+	switch {
+	case l.CollectionName == "" && l.ResourceID == "":
+		return NewInvalidParameterError("either collection_name or resource_id must be set")
+	case l.CollectionName != "" && l.ResourceID != "":
+		return NewInvalidParameterError("collection_name and resource_id cannot both be set")
+	}
+	return nil
+}
+
+// NewCollectionLocator addresses a collection by name.
+func NewCollectionLocator(collection string) CollectionLocator {
+	return CollectionLocator{CollectionName: collection}
+}
+
+// WithProject returns a copy of the locator scoped to the project.
+func (l CollectionLocator) WithProject(name string) CollectionLocator {
+	l.ProjectName = name
+	return l
+}
+
+// WithResourceID returns a copy of the locator carrying the resource id.
+func (l CollectionLocator) WithResourceID(id string) CollectionLocator {
+	l.ResourceID = id
+	return l
 }
 
 // IndexLocator extends collection metadata with the index name.
@@ -24,6 +74,37 @@ type IndexLocator struct {
 	IndexName string `json:"index_name"`
 }
 
+// Validate checks the collection addressing and that IndexName is set.
+func (l IndexLocator) Validate() error {
+	if err := l.CollectionLocator.Validate(); err != nil {
+		return err
+	}
+	if l.IndexName == "" {
+		return NewInvalidParameterError("index_name cannot be empty")
+	}
+	return nil
+}
+
+// NewIndexLocator addresses an index of the named collection.
+func NewIndexLocator(collection, index string) IndexLocator {
+	return IndexLocator{
+		CollectionLocator: NewCollectionLocator(collection),
+		IndexName:         index,
+	}
+}
+
+// WithProject returns a copy of the locator scoped to the project.
+func (l IndexLocator) WithProject(name string) IndexLocator {
+	l.ProjectName = name
+	return l
+}
+
+// WithResourceID returns a copy of the locator carrying the resource id.
+func (l IndexLocator) WithResourceID(id string) IndexLocator {
+	l.ResourceID = id
+	return l
+}
+
 type Refer struct {
 	AccountID  string `json:"account_id"`
 	InstanceNO string `json:"instance_no"`
@@ -32,7 +113,92 @@ type Refer struct {
 
 type MapStr map[string]interface{}
 
-// PaginationRequest represents pagination inputs.
+// ModelTokenUsage counts the tokens consumed by a single model.
+type ModelTokenUsage struct {
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+	ImageTokens      int64 `json:"image_tokens,omitempty"`
+	TotalTokens      int64 `json:"total_tokens"`
+}
+
+// TokenUsage maps the model identifier (name__version) to its token consumption.
+type TokenUsage map[string]ModelTokenUsage
+
+// Total sums TotalTokens across all models.
+func (u TokenUsage) Total() int64 {
+	var total int64
+	for _, usage := range u {
+		total += usage.TotalTokens
+	}
+	return total
+}
+
+// Add sums other into u model by model.
+func (u TokenUsage) Add(other TokenUsage) {
+	for name, usage := range other {
+		sum := u[name]
+		sum.PromptTokens += usage.PromptTokens
+		sum.CompletionTokens += usage.CompletionTokens
+		sum.ImageTokens += usage.ImageTokens
+		sum.TotalTokens += usage.TotalTokens
+		u[name] = sum
+	}
+}
+
+// ParseTokenUsage types a usage payload decoded into an interface{}, such as
+// EmbeddingResult.TokenUsage. A nil payload yields an empty TokenUsage.
+func ParseTokenUsage(raw interface{}) (TokenUsage, error) {
+	switch v := raw.(type) {
+	case nil:
+		return TokenUsage{}, nil
+	case TokenUsage:
+		return v, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var usage TokenUsage
+	if err := json.Unmarshal(data, &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// UnmarshalJSON decodes per-model usage. An unkeyed usage object is stored under "". A payload of
+// any other shape is reported as an error rather than dropped.
+func (u *TokenUsage) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*u = nil
+		return nil
+	}
+	out := make(TokenUsage, len(raw))
+	if _, flat := raw["total_tokens"]; flat {
+		var usage ModelTokenUsage
+		if err := json.Unmarshal(data, &usage); err != nil {
+			return err
+		}
+		out[""] = usage
+		*u = out
+		return nil
+	}
+	for name, payload := range raw {
+		var usage ModelTokenUsage
+		if err := json.Unmarshal(payload, &usage); err != nil {
+			return fmt.Errorf("token usage of %q: %w", name, err)
+		}
+		out[name] = usage
+	}
+	*u = out
+	return nil
+}
+
+// PaginationRequest represents pagination inputs. Pages start at 1; zero values use the server
+// defaults.
 type PaginationRequest struct {
 	Page     int `json:"page,omitempty"`
 	PageSize int `json:"page_size,omitempty"`
@@ -44,3 +210,13 @@ type PaginationResponse struct {
 	Page     int `json:"page"`
 	PageSize int `json:"page_size"`
 }
+
+// HasMore reports whether pages after this one hold further items.
+func (p PaginationResponse) HasMore() bool {
+	return p.PageSize > 0 && p.Page*p.PageSize < p.Total
+}
+
+// Next returns the request for the page after this one.
+func (p PaginationResponse) Next() PaginationRequest {
+	return PaginationRequest{Page: p.Page + 1, PageSize: p.PageSize}
+}
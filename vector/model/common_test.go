@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenUsageUnmarshalJSON(t *testing.T) {
+	var usage TokenUsage
+	require.NoError(t, json.Unmarshal([]byte(`{"bge-m3__default":{"prompt_tokens":3,"total_tokens":3}}`), &usage))
+	require.Equal(t, TokenUsage{"bge-m3__default": {PromptTokens: 3, TotalTokens: 3}}, usage)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"prompt_tokens":2,"total_tokens":2}`), &usage))
+	require.Equal(t, TokenUsage{"": {PromptTokens: 2, TotalTokens: 2}}, usage)
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), &usage))
+	require.Nil(t, usage)
+}
+
+func TestTokenUsageUnmarshalJSONReportsMalformedPayloads(t *testing.T) {
+	for _, payload := range []string{
+		`"12 tokens"`,
+		`{"bge-m3__default":"3"}`,
+		`{"total_tokens":"3"}`,
+	} {
+		var usage TokenUsage
+		require.Error(t, json.Unmarshal([]byte(payload), &usage), payload)
+	}
+
+	var result SearchResult
+	require.Error(t, json.Unmarshal([]byte(`{"data":[],"token_usage":[1]}`), &result), "usage errors fail the response decode")
+}
@@ -8,6 +8,9 @@ type EmbeddingModelOpt struct {
 	ModelName    *string `json:"name"`
 	ModelVersion *string `json:"version,omitempty"`
 	Dim          *int    `json:"dim,omitempty"`
+	// Normalize is sent as "normalize" to ask for L2-normalized output vectors. Leave nil to use the
+	// model's default; check the returned norms, as models without the option ignore or reject it.
+	Normalize *bool `json:"normalize,omitempty"`
 }
 
 // FullModalData represents a single multimodal element that can be embedded.
@@ -25,12 +28,24 @@ type EmbeddingData struct {
 	FullModalSeq []FullModalData `json:"full_modal_seq,omitempty"`
 }
 
+// NamedEmbeddingModel pairs dense and/or sparse models with the name their output is keyed by.
+type NamedEmbeddingModel struct {
+	Name        string
+	DenseModel  *EmbeddingModelOpt
+	SparseModel *EmbeddingModelOpt
+}
+
 // EmbeddingRequest mirrors the Java SDK request payload.
 type EmbeddingRequest struct {
 	ProjectName *string            `json:"project_name,omitempty"`
 	DenseModel  *EmbeddingModelOpt `json:"dense_model,omitempty"`
 	SparseModel *EmbeddingModelOpt `json:"sparse_model,omitempty"`
 	Data        []*EmbeddingData   `json:"data"`
+
+	// Models embeds Data with several named models in one SDK call. The SDK still issues one request
+	// per model, a few concurrently, and keys each output in Embedding.Named. It cannot be combined
+	// with DenseModel or SparseModel.
+	Models []NamedEmbeddingModel `json:"-"`
 }
 
 type EmbeddingResponse struct {
@@ -39,12 +54,17 @@ type EmbeddingResponse struct {
 }
 
 type EmbeddingResult struct {
-	Data       []*Embedding `json:"data"`
-	TokenUsage interface{}  `json:"token_usage,omitempty"`
+	Data []*Embedding `json:"data"`
+	// TokenUsage holds the server usage payload. For requests using Models it is a TokenUsage summing
+	// the usage of every model; ParseTokenUsage types the payload of other requests.
+	TokenUsage interface{} `json:"token_usage,omitempty"`
 }
 
 // Embedding contains the generated dense and sparse vectors.
 type Embedding struct {
 	DenseVectors  []float32          `json:"dense,omitempty"`
 	SparseVectors map[string]float32 `json:"sparse,omitempty"`
+
+	// Named holds the vectors per model name when the request used Models.
+	Named map[string]*Embedding `json:"-"`
 }
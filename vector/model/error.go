@@ -22,6 +22,7 @@ const (
 	ErrCodeServiceUnavailable   ErrorCode = "ServiceUnavailable"
 	ErrCodeTimeout              ErrorCode = "Timeout"
 	ErrCodeRequestLimitExceeded ErrorCode = "RequestLimitExceeded"
+	ErrCodeQuotaExceeded        ErrorCode = "QuotaExceeded"
 	ErrCodeUnauthorized         ErrorCode = "Unauthorized"
 	ErrCodeForbidden            ErrorCode = "Forbidden"
 	ErrCodeNotFound             ErrorCode = "NotFound"
@@ -48,6 +49,14 @@ const (
 	ErrCodeModelNotFound   ErrorCode = "ModelNotFound"
 )
 
+// Sentinel errors for use with errors.Is; matching compares error codes.
+var (
+	ErrModelNotFound = NewErrorWithStatusCode(ErrCodeModelNotFound, "model not found", http.StatusNotFound)
+	ErrQuotaExceeded = NewErrorWithStatusCode(ErrCodeQuotaExceeded, "account quota or balance exhausted", http.StatusPaymentRequired)
+	ErrUnauthorized  = NewUnauthorizedError("credentials rejected")
+	ErrDataNotFound  = NewErrorWithStatusCode(ErrCodeDataNotFound, "data not found", http.StatusNotFound)
+)
+
 // Error wraps a VikingDB failure with HTTP and internal metadata.
 type Error struct {
 	// Code is the VikingDB error code string.
@@ -64,14 +73,22 @@ type Error struct {
 
 	// Err contains the underlying error when available.
 	Err error `json:"-"`
+
+	// RequestBody is a size-capped snapshot of the request body with sensitive values masked. It is
+	// only set when the client is created with WithIncludeRequestInErrors.
+	RequestBody string `json:"request_body,omitempty"`
 }
 
 // Error implements the error interface.
 func (e *Error) Error() string {
+	msg := fmt.Sprintf("vikingdb error: code=%s, message=%s, status_code=%d, err=%v", e.Code, e.Message, e.StatusCode, e.Err)
 	if e.RequestID != "" {
-		return fmt.Sprintf("vikingdb error: code=%s, message=%s, status_code=%d, err=%v, request_id=%s", e.Code, e.Message, e.StatusCode, e.Err, e.RequestID)
+		msg += fmt.Sprintf(", request_id=%s", e.RequestID)
 	}
-	return fmt.Sprintf("vikingdb error: code=%s, message=%s, status_code=%d, err=%v", e.Code, e.Message, e.StatusCode, e.Err)
+	if e.RequestBody != "" {
+		msg += fmt.Sprintf(", request_body=%s", e.RequestBody)
+	}
+	return msg
 }
 
 // Unwrap returns the wrapped error for errors.Is compatibility.
@@ -79,6 +96,15 @@ func (e *Error) Unwrap() error {
 	return e.Err
 }
 
+// Is reports whether target is an *Error with the same code, so errors.Is works against sentinels.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.Code == "" {
+		return false
+	}
+	return e.Code == t.Code
+}
+
 // NewError constructs an Error with the supplied code and message.
 func NewError(code ErrorCode, message string) *Error {
 	return &Error{
@@ -128,6 +154,11 @@ func IsRetryableError(err error) bool {
 		return false
 	}
 
+	// Quota and billing failures persist until the account is topped up, whatever the status code.
+	if sdkErr.Code == ErrCodeQuotaExceeded {
+		return false
+	}
+
 	switch sdkErr.StatusCode {
 	case http.StatusTooManyRequests,
 		http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
@@ -176,3 +207,34 @@ func NewTimeoutError(message string) *Error {
 func NewRequestLimitExceededError(message string) *Error {
 	return NewErrorWithStatusCode(ErrCodeRequestLimitExceeded, message, http.StatusTooManyRequests)
 }
+
+// NewQuotaExceededError returns a non-retryable error for exhausted account quota or balance.
+func NewQuotaExceededError(message string) *Error {
+	return NewErrorWithStatusCode(ErrCodeQuotaExceeded, message, http.StatusPaymentRequired)
+}
+
+// DataNotFoundError lists the ids a fetch asked for but did not find. It unwraps to an *Error with
+// ErrCodeDataNotFound, so errors.Is(err, ErrDataNotFound) matches it.
+type DataNotFoundError struct {
+	IDs []interface{}
+
+	err *Error
+}
+
+// NewDataNotFoundError returns a DataNotFoundError for ids.
+func NewDataNotFoundError(ids []interface{}) *DataNotFoundError {
+	return &DataNotFoundError{
+		IDs: ids,
+		err: NewErrorWithStatusCode(ErrCodeDataNotFound, fmt.Sprintf("%d ids not found: %v", len(ids), ids), http.StatusNotFound),
+	}
+}
+
+// Error implements the error interface.
+func (e *DataNotFoundError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying *Error.
+func (e *DataNotFoundError) Unwrap() error {
+	return e.err
+}
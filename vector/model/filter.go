@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+// FilterOp names a filter operator, the value of the "op" key.
+type FilterOp string
+
+// Filter operators.
+const (
+	OpMust      FilterOp = "must"
+	OpMustNot   FilterOp = "must_not"
+	OpRange     FilterOp = "range"
+	OpRangeOut  FilterOp = "range_out"
+	OpAnd       FilterOp = "and"
+	OpOr        FilterOp = "or"
+	OpPrefix    FilterOp = "prefix"
+	OpContains  FilterOp = "contains"
+	OpRegex     FilterOp = "regex"
+	OpIsNull    FilterOp = "is_null"
+	OpIsNotNull FilterOp = "is_not_null"
+)
+
+// Filter keys, used as MapStr keys when building filters by hand.
+const (
+	FilterKeyOp    = "op"
+	FilterKeyField = "field"
+	FilterKeyConds = "conds"
+)
+
+// Range bounds, used as MapStr keys of range and range_out filters.
+const (
+	CondGt  = "gt"
+	CondGte = "gte"
+	CondLt  = "lt"
+	CondLte = "lte"
+)
+
+// IsNull matches documents where field is not set or holds null.
+// The server must support the is_null filter op for the field's index.
+func IsNull(field string) MapStr {
+	return MapStr{
+		FilterKeyOp:    OpIsNull,
+		FilterKeyField: field,
+	}
+}
+
+// IsNotNull matches documents where field is set to a non-null value.
+func IsNotNull(field string) MapStr {
+	return MapStr{
+		FilterKeyOp:    OpIsNotNull,
+		FilterKeyField: field,
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultRRFK is the rank constant used by ReciprocalRankFusion when k is not positive.
+const DefaultRRFK = 60
+
+// ReciprocalRankFusion merges ranked result sets by id. Each hit contributes 1/(k+rank) with rank
+// starting at 1; the fused value replaces Score and hits are returned by descending fused score.
+// Fields of the same id across sets are merged, with earlier sets taking precedence.
+func ReciprocalRankFusion(k int, resultSets ...[]SearchItemResult) []SearchItemResult {
+	return fuseRanks(k, nil, resultSets)
+}
+
+// WeightedReciprocalRankFusion is ReciprocalRankFusion with one weight per result set: each hit of
+// resultSets[i] contributes weights[i]/(k+rank), so a set weighted 2 counts twice as much as one
+// weighted 1. It fails when the weight and set counts differ or a weight is negative.
+func WeightedReciprocalRankFusion(k int, weights []float64, resultSets ...[]SearchItemResult) ([]SearchItemResult, error) {
+	if len(weights) != len(resultSets) {
+		return nil, NewInvalidParameterError(fmt.Sprintf("got %d weights for %d result sets", len(weights), len(resultSets)))
+	}
+	for i, weight := range weights {
+		if weight < 0 {
+			return nil, NewInvalidParameterError(fmt.Sprintf("weights[%d] cannot be negative", i))
+		}
+	}
+	return fuseRanks(k, weights, resultSets), nil
+}
+
+// fuseRanks implements both fusions; nil weights weigh every set 1.
+func fuseRanks(k int, weights []float64, resultSets [][]SearchItemResult) []SearchItemResult {
+	if k <= 0 {
+		k = DefaultRRFK
+	}
+
+	type fused struct {
+		item  SearchItemResult
+		score float64
+		order int
+	}
+	byID := make(map[interface{}]*fused)
+	ordered := make([]*fused, 0)
+
+	for set, results := range resultSets {
+		weight := 1.0
+		if weights != nil {
+			weight = weights[set]
+		}
+		for rank, hit := range results {
+			key := IDKey(hit.ID)
+			entry, ok := byID[key]
+			if !ok {
+				item := hit
+				item.Fields = make(MapStr, len(hit.Fields))
+				for name, value := range hit.Fields {
+					item.Fields[name] = value
+				}
+				entry = &fused{item: item, order: len(ordered)}
+				byID[key] = entry
+				ordered = append(ordered, entry)
+			} else {
+				for name, value := range hit.Fields {
+					if _, exists := entry.item.Fields[name]; !exists {
+						entry.item.Fields[name] = value
+					}
+				}
+			}
+			entry.score += weight / float64(k+rank+1)
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].score != ordered[j].score {
+			return ordered[i].score > ordered[j].score
+		}
+		return ordered[i].order < ordered[j].order
+	})
+
+	out := make([]SearchItemResult, len(ordered))
+	for i, entry := range ordered {
+		out[i] = entry.item
+		out[i].Score = float32(entry.score)
+	}
+	return out
+}
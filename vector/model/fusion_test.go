@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func hitIDs(hits []SearchItemResult) []interface{} {
+	ids := make([]interface{}, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	return ids
+}
+
+func TestWeightedReciprocalRankFusion(t *testing.T) {
+	image := []SearchItemResult{{ID: "b"}, {ID: "a"}}
+	text := []SearchItemResult{{ID: "a"}, {ID: "c"}}
+
+	// Unweighted, "a" wins by appearing in both lists.
+	require.Equal(t, []interface{}{"a", "b", "c"}, hitIDs(ReciprocalRankFusion(1, image, text)))
+
+	fused, err := WeightedReciprocalRankFusion(1, []float64{4, 1}, image, text)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"b", "a", "c"}, hitIDs(fused))
+	require.InDelta(t, 4.0/2, fused[0].Score, 1e-6)
+	require.InDelta(t, 4.0/3+1.0/2, fused[1].Score, 1e-6)
+
+	equal, err := WeightedReciprocalRankFusion(1, []float64{1, 1}, image, text)
+	require.NoError(t, err)
+	require.Equal(t, ReciprocalRankFusion(1, image, text), equal)
+
+	_, err = WeightedReciprocalRankFusion(1, []float64{1}, image, text)
+	require.Error(t, err)
+	_, err = WeightedReciprocalRankFusion(1, []float64{1, -1}, image, text)
+	require.Error(t, err)
+}
+
+func TestReciprocalRankFusion(t *testing.T) {
+	dense := []SearchItemResult{
+		{ID: int64(1), Score: 0.9, Fields: MapStr{"title": "dense"}},
+		{ID: int64(2), Score: 0.8},
+	}
+	sparse := []SearchItemResult{
+		{ID: 2.0, Score: 12, Fields: MapStr{"title": "sparse", "body": "b"}},
+		{ID: "x", Score: 3},
+	}
+
+	fused := ReciprocalRankFusion(0, dense, sparse)
+	require.Equal(t, []interface{}{int64(2), int64(1), "x"}, hitIDs(fused), "2 and 2.0 are one document, keeping the first set's id")
+	require.InDelta(t, 1.0/62+1.0/61, fused[0].Score, 1e-6, "k defaults to DefaultRRFK")
+	require.Equal(t, MapStr{"title": "sparse", "body": "b"}, fused[0].Fields)
+	require.Equal(t, int64(1), fused[1].ID)
+	require.InDelta(t, 1.0/61, fused[1].Score, 1e-6)
+	require.Equal(t, "x", fused[2].ID)
+
+	require.Equal(t, MapStr{"title": "dense"}, dense[0].Fields, "inputs are not modified")
+	require.Empty(t, ReciprocalRankFusion(60))
+}
+
+func TestReciprocalRankFusionMergesFieldsWithEarlierSetsFirst(t *testing.T) {
+	first := []SearchItemResult{{ID: "a", Fields: MapStr{"title": "first"}}}
+	second := []SearchItemResult{{ID: "a", Fields: MapStr{"title": "second", "body": "only here"}}}
+
+	fused := ReciprocalRankFusion(1, first, second)
+	require.Equal(t, MapStr{"title": "first", "body": "only here"}, fused[0].Fields)
+}
+
+func TestReciprocalRankFusionBreaksTiesByFirstAppearance(t *testing.T) {
+	a := []SearchItemResult{{ID: "p"}, {ID: "q"}}
+	b := []SearchItemResult{{ID: "q"}, {ID: "p"}}
+
+	require.Equal(t, []interface{}{"p", "q"}, hitIDs(ReciprocalRankFusion(1, a, b)))
+	require.Equal(t, []interface{}{"q", "p"}, hitIDs(ReciprocalRankFusion(1, b, a)))
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+// maxExactFloat is the largest integer a float64 represents exactly.
+const maxExactFloat = 1 << 53
+
+// ID is a canonical primary key. Integer keys from any numeric representation compare equal, while
+// string keys never compare equal to integer keys. The zero value is the integer key 0.
+type ID struct {
+	isString bool
+	num      int64
+	str      string
+}
+
+// ParseID canonicalizes a primary key given as an integer, float, json.Number, or string. It fails when a
+// number is fractional, not finite, or cannot be represented as int64 without losing precision.
+func ParseID(raw interface{}) (ID, error) {
+	switch v := raw.(type) {
+	case string:
+		return ID{isString: true, str: v}, nil
+	case int:
+		return ID{num: int64(v)}, nil
+	case int8:
+		return ID{num: int64(v)}, nil
+	case int16:
+		return ID{num: int64(v)}, nil
+	case int32:
+		return ID{num: int64(v)}, nil
+	case int64:
+		return ID{num: v}, nil
+	case uint:
+		return parseUintID(uint64(v))
+	case uint8:
+		return ID{num: int64(v)}, nil
+	case uint16:
+		return ID{num: int64(v)}, nil
+	case uint32:
+		return ID{num: int64(v)}, nil
+	case uint64:
+		return parseUintID(v)
+	case float32:
+		return parseFloatID(float64(v))
+	case float64:
+		return parseFloatID(v)
+	case json.Number:
+		if n, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return ID{num: n}, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return ID{}, NewInvalidParameterError(fmt.Sprintf("id %s is not a valid int64", v))
+		}
+		return parseFloatID(f)
+	case ID:
+		return v, nil
+	case nil:
+		return ID{}, NewInvalidParameterError("id cannot be nil")
+	}
+	return ID{}, NewInvalidParameterError(fmt.Sprintf("unsupported id type %T", raw))
+}
+
+func parseUintID(v uint64) (ID, error) {
+	if v > math.MaxInt64 {
+		return ID{}, NewInvalidParameterError(fmt.Sprintf("id %d overflows int64", v))
+	}
+	return ID{num: int64(v)}, nil
+}
+
+func parseFloatID(f float64) (ID, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return ID{}, NewInvalidParameterError(fmt.Sprintf("id %v is not an integer", f))
+	}
+	if math.Abs(f) > maxExactFloat {
+		return ID{}, NewInvalidParameterError(fmt.Sprintf("id %v exceeds the exact float range; pass it as int64 or json.Number", f))
+	}
+	return ID{num: int64(f)}, nil
+}
+
+// IsString reports whether the key is a string key.
+func (id ID) IsString() bool {
+	return id.isString
+}
+
+// Value returns the key as int64 or string, ready to be sent in a request.
+func (id ID) Value() interface{} {
+	if id.isString {
+		return id.str
+	}
+	return id.num
+}
+
+// String formats the key for logs and map keys.
+func (id ID) String() string {
+	if id.isString {
+		return id.str
+	}
+	return strconv.FormatInt(id.num, 10)
+}
+
+// MarshalJSON encodes the key as a JSON number or string.
+func (id ID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.Value())
+}
+
+// CanonicalIDs parses every id with ParseID and returns their request values, so numerically equal
+// keys are sent identically regardless of how they were obtained. A collection has a single primary key
+// type, so mixing string and integer keys is rejected rather than letting some of them match nothing.
+// Fetch and Delete apply it to their ids: integral floats and json.Number values are sent as integers,
+// while an id ParseID rejects, such as 1.5, or a mixed list fails locally without a request.
+func CanonicalIDs(ids []interface{}) ([]interface{}, error) {
+	if ids == nil {
+		return nil, nil
+	}
+	out := make([]interface{}, len(ids))
+	var first ID
+	for idx, raw := range ids {
+		id, err := ParseID(raw)
+		if err != nil {
+			return nil, NewErrorWithCause(ErrCodeInvalidParameter, fmt.Sprintf("ids[%d] is invalid", idx), err, http.StatusBadRequest)
+		}
+		if idx == 0 {
+			first = id
+		} else if id.isString != first.isString {
+			return nil, NewInvalidParameterError(fmt.Sprintf("ids mix string and integer keys: ids[0] is %s, ids[%d] is %s", idKind(first), idx, idKind(id)))
+		}
+		out[idx] = id.Value()
+	}
+	return out, nil
+}
+
+// IDs normalizes primary keys for a request such as DeleteDataRequest.IDs: integers of any type,
+// integral floats, and json.Number values read from earlier responses all become int64, strings stay
+// strings, and mixing the two kinds is an error.
+func IDs(vals ...interface{}) ([]interface{}, error) {
+	if len(vals) == 0 {
+		return []interface{}{}, nil
+	}
+	return CanonicalIDs(vals)
+}
+
+func idKind(id ID) string {
+	if id.isString {
+		return fmt.Sprintf("string %q", id.str)
+	}
+	return fmt.Sprintf("integer %d", id.num)
+}
+
+// IDKey returns a comparable map key for raw: its canonical ID when it parses, otherwise raw formatted
+// with %v.
+func IDKey(raw interface{}) interface{} {
+	if id, err := ParseID(raw); err == nil {
+		return id
+	}
+	return fmt.Sprintf("%v", raw)
+}
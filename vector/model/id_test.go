@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDsNormalizesNumbers(t *testing.T) {
+	ids, err := IDs(1, int64(2), json.Number("3"), 4.0, uint32(5))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int64(1), int64(2), int64(3), int64(4), int64(5)}, ids)
+
+	ids, err = IDs("a", "b")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a", "b"}, ids)
+}
+
+func TestIDsRejectsMixedKinds(t *testing.T) {
+	_, err := IDs(json.Number("1"), "2")
+	require.True(t, errors.Is(err, NewInvalidParameterError("")), "got %v", err)
+	require.Contains(t, err.Error(), "ids[1]")
+
+	_, err = IDs(1.5)
+	require.Error(t, err)
+}
+
+func TestZeroIDIsIntegerZero(t *testing.T) {
+	zero, err := ParseID(0)
+	require.NoError(t, err)
+	require.Equal(t, ID{}, zero)
+	require.Equal(t, int64(0), ID{}.Value())
+}
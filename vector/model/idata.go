@@ -3,8 +3,16 @@
 
 package model
 
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
 // FetchDataInIndexRequest fetches documents (and optional vectors) from an index.
 type FetchDataInIndexRequest struct {
+	// IDs are normalized by CanonicalIDs before sending; an id it rejects fails the call unsent.
 	IDs          []interface{} `json:"ids"`
 	Partition    string        `json:"partition,omitempty"` // advanced feature, support string&int partition
 	OutputFields []string      `json:"output_fields,omitempty"`
@@ -14,6 +22,19 @@ type IndexDataItem struct {
 	DataItem
 	DenseDim    int       `json:"dense_dim,omitempty"`
 	DenseVector []float32 `json:"dense_vector,omitempty"`
+	// DenseVectors holds every dense vector of a multi-vector collection keyed by field name. It is
+	// empty for single-vector collections, which only fill DenseVector.
+	DenseVectors map[string][]float32 `json:"dense_vectors,omitempty"`
+}
+
+// Vector returns the dense vector stored in field. For single-vector collections, whose DenseVectors
+// is empty, it returns DenseVector whatever the field name.
+func (i IndexDataItem) Vector(field string) ([]float32, bool) {
+	if len(i.DenseVectors) == 0 {
+		return i.DenseVector, len(i.DenseVector) > 0
+	}
+	vec, ok := i.DenseVectors[field]
+	return vec, ok
 }
 
 // FetchDataInIndexResponse mirrors DataApiResponse<FetchDataInIndexResult>.
@@ -27,6 +48,20 @@ type FetchDataInIndexResult struct {
 	NotFoundIDs []interface{}   `json:"ids_not_exist,omitempty"`
 }
 
+// Item returns the fetched document whose primary key equals id under ParseID canonicalization.
+func (r *FetchDataInIndexResult) Item(id interface{}) (IndexDataItem, bool) {
+	want, err := ParseID(id)
+	if r == nil || err != nil {
+		return IndexDataItem{}, false
+	}
+	for _, item := range r.Items {
+		if got, err := ParseID(item.ID); err == nil && got == want {
+			return item, true
+		}
+	}
+	return IndexDataItem{}, false
+}
+
 // RecallBase carries shared search filters.
 type RecallBase struct {
 	Filter    MapStr `json:"filter,omitempty"`
@@ -37,16 +72,21 @@ type RecallBase struct {
 type SearchBase struct {
 	RecallBase
 
-	OutputFields []string       `json:"output_fields,omitempty"`
-	Limit        *int           `json:"limit,omitempty"`
-	Offset       *int           `json:"offset,omitempty"`
-	Advance      *SearchAdvance `json:"advance,omitempty"`
+	OutputFields []string `json:"output_fields,omitempty"`
+	Limit        *int     `json:"limit,omitempty"`
+	// Offset skips leading hits. The server caps offset+limit at its maximum result window and rejects
+	// deeper pages with an InvalidParameter error; narrow the Filter to reach further results.
+	Offset  *int           `json:"offset,omitempty"`
+	Advance *SearchAdvance `json:"advance,omitempty"`
 }
 
 // SearchAdvance maps to Java's SearchAdvance DTO.
 type SearchAdvance struct {
-	DenseWeight           *float64      `json:"dense_weight,omitempty"`
-	IDsIn                 []interface{} `json:"ids_in,omitempty"`
+	DenseWeight *float64 `json:"dense_weight,omitempty"`
+	// IDsIn restricts recall to the listed primary keys. It is ANDed with RecallBase.Filter, so a hit
+	// must be in IDsIn and match Filter before it is ranked by the query vector.
+	IDsIn []interface{} `json:"ids_in,omitempty"`
+	// IDsNotIn excludes the listed primary keys, also ANDed with Filter.
 	IDsNotIn              []interface{} `json:"ids_not_in,omitempty"`
 	PostProcessOps        []MapStr      `json:"post_process_ops,omitempty"`
 	PostProcessInputLimit *int          `json:"post_process_input_limit,omitempty"`
@@ -65,25 +105,138 @@ type SearchResult struct {
 	FilterMatchedCount int                `json:"filter_matched_count,omitempty"`
 	TotalReturnCount   int                `json:"total_return_count,omitempty"`
 	RealTextQuery      string             `json:"real_text_query,omitempty"`
-	TokenUsage         MapStr             `json:"token_usage,omitempty"`
+	// TokenUsage reports the tokens the server spent embedding the query, when it reports any.
+	TokenUsage TokenUsage `json:"token_usage,omitempty"`
 }
 
 // SearchItemResult represents a single hit within a search response.
 type SearchItemResult struct {
-	ID       interface{} `json:"id"`
-	Fields   MapStr      `json:"fields,omitempty"`
-	ANNScore float32     `json:"ann_score,omitempty"`
-	Score    float32     `json:"score,omitempty"`
+	ID     interface{} `json:"id"`
+	Fields MapStr      `json:"fields,omitempty"`
+	// ANNScore is the raw score computed by the vector index for the index metric, before any hybrid
+	// weighting or post-processing. Whether higher means closer depends on the metric.
+	ANNScore float32 `json:"ann_score,omitempty"`
+	// Score is the final ranking score after hybrid weighting and post-processing; hits are ordered by it.
+	Score float32 `json:"score,omitempty"`
+	// Distance is the raw metric distance (e.g. L2) when the server reports it, zero otherwise.
+	Distance float32 `json:"distance,omitempty"`
+	// Partition is the partition the hit was recalled from, when the server reports one in the
+	// hit's "partition" key; otherwise it is empty.
+	Partition HitPartition `json:"partition,omitempty"`
+}
+
+// HitPartition holds a hit's partition value. Integer partitions are kept in their decimal form so
+// string and int partitioned collections decode alike.
+type HitPartition string
+
+// UnmarshalJSON accepts the partition as a JSON string or number.
+func (p *HitPartition) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*p = HitPartition(value)
+		return nil
+	}
+	if string(data) == "null" {
+		*p = ""
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	*p = HitPartition(number.String())
+	return nil
+}
+
+// SearchRequest is implemented by the SearchBy*Request types only, so APIs that run any kind of
+// search, such as IndexClient.SearchAndFetch, reject other values at compile time.
+type SearchRequest interface {
+	searchRequest()
 }
 
+func (SearchByVectorRequest) searchRequest()     {}
+func (SearchByMultiModalRequest) searchRequest() {}
+func (SearchByIDRequest) searchRequest()         {}
+func (SearchByScalarRequest) searchRequest()     {}
+func (SearchByKeywordsRequest) searchRequest()   {}
+func (SearchByRandomRequest) searchRequest()     {}
+
 // SearchByVectorRequest performs vector similarity search.
 type SearchByVectorRequest struct {
 	SearchBase
-	DenseVector  []float64          `json:"dense_vector"`
-	SparseVector map[string]float64 `json:"sparse_vector,omitempty"`
+	DenseVector []float64 `json:"dense_vector"`
+	// DenseVectorF32 is sent as dense_vector in place of DenseVector, so embedding output can be
+	// searched without conversion. Set at most one of the two.
+	DenseVectorF32 []float32    `json:"-"`
+	SparseVector   SparseVector `json:"sparse_vector,omitempty"`
+	// Metric is sent as "metric" to request a query-time distance metric, one of MetricIP, MetricL2,
+	// or MetricCosine. The SDK only validates the value; whether an index honours it is up to the
+	// server.
+	Metric *string `json:"metric,omitempty"`
+}
+
+// Distance metrics accepted by SearchByVectorRequest.Metric.
+const (
+	MetricIP     = "ip"
+	MetricL2     = "l2"
+	MetricCosine = "cosine"
+)
+
+// Validate checks pagination and advance settings shared by every search.
+func (b SearchBase) Validate() error {
+	if b.Limit != nil && *b.Limit <= 0 {
+		return NewInvalidParameterError("limit must be positive")
+	}
+	if b.Offset != nil && *b.Offset < 0 {
+		return NewInvalidParameterError("offset cannot be negative")
+	}
+	if b.Advance != nil && b.Advance.DenseWeight != nil {
+		if w := *b.Advance.DenseWeight; w < 0 || w > 1 || math.IsNaN(w) {
+			return NewInvalidParameterError("advance.dense_weight must be within [0, 1]")
+		}
+	}
+	return nil
 }
 
-// SearchByMultiModalRequest performs multimodal search.
+// Validate checks the whole request before it is sent: the shared search settings, that a dense
+// vector is present, that every vector component is finite, and that sparse keys are well formed.
+func (r SearchByVectorRequest) Validate() error {
+	if err := r.SearchBase.Validate(); err != nil {
+		return err
+	}
+	if len(r.DenseVector) > 0 && len(r.DenseVectorF32) > 0 {
+		return NewInvalidParameterError("set either DenseVector or DenseVectorF32, not both")
+	}
+	if len(r.DenseVector) == 0 && len(r.DenseVectorF32) == 0 {
+		return NewInvalidParameterError("dense_vector cannot be empty")
+	}
+	for idx, v := range r.DenseVector {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return NewInvalidParameterError(fmt.Sprintf("dense_vector[%d] is not a finite number", idx))
+		}
+	}
+	for idx, v := range r.DenseVectorF32 {
+		if f := float64(v); math.IsNaN(f) || math.IsInf(f, 0) {
+			return NewInvalidParameterError(fmt.Sprintf("dense_vector[%d] is not a finite number", idx))
+		}
+	}
+	if r.Metric != nil {
+		switch *r.Metric {
+		case MetricIP, MetricL2, MetricCosine:
+		default:
+			return NewInvalidParameterError(fmt.Sprintf("metric %q must be one of %s, %s, %s", *r.Metric, MetricIP, MetricL2, MetricCosine))
+		}
+	}
+	return r.SparseVector.Validate(0)
+}
+
+// SearchByMultiModalRequest performs multimodal search. Text, Image, and Video may be combined, e.g.
+// a photo plus a description, against an index whose vectorize model accepts every modality given.
+// The server embeds the combined input as one query vector, so there is no per-modality weight; to
+// favour one modality, search each separately and fuse the hits with WeightedReciprocalRankFusion.
 type SearchByMultiModalRequest struct {
 	SearchBase
 	Text            *string     `json:"text,omitempty"`
@@ -106,19 +259,72 @@ const (
 	ScalarOrderDesc ScalarOrder = "desc"
 )
 
-// SearchByScalarRequest orders results by scalar field.
+// SearchByScalarRequest returns the documents matching Filter ordered by a scalar field, without any
+// similarity scoring.
 type SearchByScalarRequest struct {
 	SearchBase
 	Field *string     `json:"field,omitempty"`
 	Order ScalarOrder `json:"order,omitempty"`
 }
 
-// SearchByKeywordsRequest matches documents by keywords.
+// Validate checks the shared search settings, that Field is not blank when set, and that Order is
+// ScalarOrderAsc or ScalarOrderDesc when set.
+func (r SearchByScalarRequest) Validate() error {
+	if err := r.SearchBase.Validate(); err != nil {
+		return err
+	}
+	if r.Field != nil && strings.TrimSpace(*r.Field) == "" {
+		return NewInvalidParameterError("field cannot be blank")
+	}
+	switch r.Order {
+	case "", ScalarOrderAsc, ScalarOrderDesc:
+	default:
+		return NewInvalidParameterError(fmt.Sprintf("order %q must be %s or %s", r.Order, ScalarOrderAsc, ScalarOrderDesc))
+	}
+	return nil
+}
+
+// SearchByKeywordsRequest matches documents by keywords. Keywords and Query are alternative ways to
+// express the match and at least one is required:
+//   - Keywords lists terms matched verbatim; a document matching any term is a hit.
+//   - Query is free text that the index's analyzer splits into terms before matching, so it behaves
+//     like Keywords filled with the analyzer's tokens.
+//
+// Set only one of them; combining both is not supported by every server version.
 type SearchByKeywordsRequest struct {
 	SearchBase
 	Keywords      []string `json:"keywords,omitempty"`
 	Query         string   `json:"query,omitempty"`
 	CaseSensitive bool     `json:"case_sensitive,omitempty"`
+	// FieldBoosts is sent as "field_boosts" to weight keyword matches per text field, e.g.
+	// {"title": 2, "body": 1}. The SDK only validates the weights; servers without field boosts
+	// ignore or reject it.
+	FieldBoosts map[string]float64 `json:"field_boosts,omitempty"`
+}
+
+// Validate checks the shared search settings, that Keywords or Query is set, and that every field
+// boost is a positive finite number.
+func (r SearchByKeywordsRequest) Validate() error {
+	if err := r.SearchBase.Validate(); err != nil {
+		return err
+	}
+	if len(r.Keywords) == 0 && strings.TrimSpace(r.Query) == "" {
+		return NewInvalidParameterError("keywords search needs keywords or query")
+	}
+	for idx, keyword := range r.Keywords {
+		if strings.TrimSpace(keyword) == "" {
+			return NewInvalidParameterError(fmt.Sprintf("keywords[%d] cannot be blank", idx))
+		}
+	}
+	for field, boost := range r.FieldBoosts {
+		if field == "" {
+			return NewInvalidParameterError("field_boosts keys cannot be empty")
+		}
+		if boost <= 0 || math.IsNaN(boost) || math.IsInf(boost, 0) {
+			return NewInvalidParameterError(fmt.Sprintf("field_boosts[%q] must be a positive finite number", field))
+		}
+	}
+	return nil
 }
 
 // SearchByRandomRequest randomly samples documents.
@@ -133,6 +339,9 @@ type AggRequest struct {
 	Field *string     `json:"field,omitempty"`
 	Cond  MapStr      `json:"cond,omitempty"`
 	Order ScalarOrder `json:"order,omitempty"`
+	// OutputFields is sent as "output_fields" to project sample documents, for servers and ops that
+	// return them in AggResult.Docs.
+	OutputFields []string `json:"output_fields,omitempty"`
 }
 
 type AggResponse struct {
@@ -140,8 +349,110 @@ type AggResponse struct {
 	Result *AggResult `json:"result,omitempty"`
 }
 
+// AggOpCount is the aggregate op that counts matching documents.
+const AggOpCount = "count"
+
+// AggTotalKey is the key under which the server reports an ungrouped aggregate value.
+const AggTotalKey = "__TOTAL__"
+
 type AggResult struct {
 	Agg   MapStr `json:"agg,omitempty"`
 	Op    string `json:"op,omitempty"`
 	Field string `json:"field,omitempty"`
+	// Docs holds the sample documents of each group, keyed like Agg, when the server returns a "docs"
+	// object; it is empty otherwise.
+	Docs map[string][]SearchItemResult `json:"docs,omitempty"`
+
+	// Count is populated by the SDK for count aggregations. It holds the __TOTAL__ value when
+	// present, otherwise the sum of all group counts in Agg.
+	Count int64 `json:"-"`
+}
+
+// AggSpec names one aggregation of a MultiAggRequest.
+type AggSpec struct {
+	Op string
+	// Field is the aggregated field; leave it empty for ops that take none.
+	Field string
+}
+
+// Key returns the key of the spec's result in MultiAggResponse.Results: "op/field", or just "op" when
+// Field is empty.
+func (s AggSpec) Key() string {
+	if s.Field == "" {
+		return s.Op
+	}
+	return s.Op + "/" + s.Field
+}
+
+// MultiAggRequest lists several aggregations over the same documents for
+// IndexClient.AggregateConcurrent, e.g. count, min, max, and avg of one field. Filter, Partition, and
+// Cond apply to every op; each op is still its own request.
+type MultiAggRequest struct {
+	RecallBase
+	Ops  []AggSpec
+	Cond MapStr
+	// Concurrency caps the op requests in flight, 4 by default.
+	Concurrency int
+}
+
+// Validate checks that at least one op is given, every op is named, and no op repeats.
+func (r MultiAggRequest) Validate() error {
+	if len(r.Ops) == 0 {
+		return NewInvalidParameterError("ops cannot be empty")
+	}
+	if r.Concurrency < 0 {
+		return NewInvalidParameterError("concurrency cannot be negative")
+	}
+	seen := make(map[string]bool, len(r.Ops))
+	for idx, spec := range r.Ops {
+		if strings.TrimSpace(spec.Op) == "" {
+			return NewInvalidParameterError(fmt.Sprintf("ops[%d].op cannot be blank", idx))
+		}
+		if seen[spec.Key()] {
+			return NewInvalidParameterError(fmt.Sprintf("ops[%d] repeats %s", idx, spec.Key()))
+		}
+		seen[spec.Key()] = true
+	}
+	return nil
+}
+
+// MultiAggResponse holds the result of every op of a MultiAggRequest.
+type MultiAggResponse struct {
+	// Results maps AggSpec.Key to that op's result.
+	Results map[string]*AggResult
+	// RequestIDs lists the request id of each op in MultiAggRequest.Ops order.
+	RequestIDs []string
+}
+
+// ExportOptions controls IndexClient.Export.
+type ExportOptions struct {
+	// OrderField is the scalar field pages are sorted and resumed by. It must be indexed for scalar
+	// search, accept range filters, be returned in hit fields, and not change while the export runs.
+	// Ties are handled, but a unique field keeps every page at PageSize rows.
+	OrderField string
+	// OutputFields restricts the exported fields; empty exports all fields.
+	OutputFields []string
+	// IncludeVectors fetches each document's dense vector alongside its fields.
+	IncludeVectors bool
+	// PageSize is the number of documents read per call, 100 by default.
+	PageSize int
+	// Filter optionally restricts the exported documents.
+	Filter MapStr
+}
+
+// HitMeta carries a search hit's id and scores. Embed it in the structs passed to vector.DecodeHits,
+// which fills it from each hit; its fields never clash with document fields.
+type HitMeta struct {
+	ID        interface{}  `json:"-"`
+	Score     float32      `json:"-"`
+	ANNScore  float32      `json:"-"`
+	Partition HitPartition `json:"-"`
+}
+
+// SetHitMeta copies the id and scores of hit.
+func (m *HitMeta) SetHitMeta(hit SearchItemResult) {
+	m.ID = hit.ID
+	m.Score = hit.Score
+	m.ANNScore = hit.ANNScore
+	m.Partition = hit.Partition
 }
@@ -142,3 +142,10 @@ type UpdateIndexResponse struct {
 	// 索引信息
 	Index *Index `json:"index,omitempty"`
 }
+
+// IndexStats carries the figures IndexClient.Stats derives from a count aggregation.
+type IndexStats struct {
+	// RowCount is the number of documents the index serves, within the client's default partition
+	// when one is configured.
+	RowCount int64
+}
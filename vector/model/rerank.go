@@ -10,6 +10,13 @@ type RerankRequest struct {
 	Query            []FullModalData   `json:"query"`
 	Instruction      *string           `json:"instruction,omitempty"`
 	ReturnOriginData *bool             `json:"return_origin_data,omitempty"`
+
+	// ChunkSize, when positive, caps the candidates sent per call. Larger Data is split into chunks
+	// reranked against the same query and merged by score, with RerankItem.ID indexing the full Data.
+	// Zero sends all of Data in one call.
+	ChunkSize int `json:"-"`
+	// ChunkConcurrency caps the chunk calls in flight, 4 by default.
+	ChunkConcurrency int `json:"-"`
 }
 
 type RerankResponse struct {
@@ -18,8 +25,10 @@ type RerankResponse struct {
 }
 
 type RerankResult struct {
-	Data       []RerankItem `json:"data"`
-	TokenUsage interface{}  `json:"token_usage,omitempty"`
+	Data []RerankItem `json:"data"`
+	// TokenUsage holds the server usage payload. For chunked requests it lists each chunk's usage
+	// in chunk order.
+	TokenUsage interface{} `json:"token_usage,omitempty"`
 }
 
 // RerankItem contains the id, score and origin data.
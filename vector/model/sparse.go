@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+)
+
+// MaxSparseKeyLength is the longest sparse vector key, in bytes, accepted by Validate.
+const MaxSparseKeyLength = 256
+
+// SparseVector maps a term or token id to its weight.
+type SparseVector map[string]float64
+
+// Validate checks every key is non-empty and at most MaxSparseKeyLength bytes, every weight is finite,
+// and, when maxNonZeros is positive, that the vector holds at most maxNonZeros entries.
+func (v SparseVector) Validate(maxNonZeros int) error {
+	if maxNonZeros > 0 && len(v) > maxNonZeros {
+		return NewInvalidParameterError(fmt.Sprintf("sparse vector has %d non-zero entries, exceeding the limit of %d; prune it before sending", len(v), maxNonZeros))
+	}
+	for key, weight := range v {
+		if key == "" {
+			return NewInvalidParameterError("sparse vector key cannot be empty")
+		}
+		if len(key) > MaxSparseKeyLength {
+			return NewInvalidParameterError(fmt.Sprintf("sparse vector key %.32q... is %d bytes, exceeding the limit of %d", key, len(key), MaxSparseKeyLength))
+		}
+		if math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return NewInvalidParameterError(fmt.Sprintf("sparse vector weight for %q is not a finite number", key))
+		}
+	}
+	return nil
+}
+
+// PruneTopK returns a copy holding the k entries of largest absolute weight, ties broken by key. The
+// vector itself is returned when it already has at most k entries or k is not positive.
+func (v SparseVector) PruneTopK(k int) SparseVector {
+	if k <= 0 || len(v) <= k {
+		return v
+	}
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		wi, wj := math.Abs(v[keys[i]]), math.Abs(v[keys[j]])
+		if wi != wj {
+			return wi > wj
+		}
+		return keys[i] < keys[j]
+	})
+	pruned := make(SparseVector, k)
+	for _, key := range keys[:k] {
+		pruned[key] = v[key]
+	}
+	return pruned
+}
+
+// PruneSparseFields applies PruneTopK to every sparse vector value held by the records, using the same
+// value types as ValidateSparseFields. Records are copied before being changed, so the input is left
+// untouched; records without oversized sparse vectors are returned as they are.
+func PruneSparseFields(records []MapStr, k int) []MapStr {
+	if k <= 0 {
+		return records
+	}
+	out := records
+	copied := false
+	for idx, record := range records {
+		var replaced MapStr
+		for field, value := range record {
+			var pruned interface{}
+			switch v := value.(type) {
+			case SparseVector:
+				if len(v) > k {
+					pruned = v.PruneTopK(k)
+				}
+			case map[string]float64:
+				if len(v) > k {
+					pruned = map[string]float64(SparseVector(v).PruneTopK(k))
+				}
+			case map[string]float32:
+				if len(v) > k {
+					wide := make(SparseVector, len(v))
+					for key, weight := range v {
+						wide[key] = float64(weight)
+					}
+					narrow := make(map[string]float32, k)
+					for key := range wide.PruneTopK(k) {
+						narrow[key] = v[key]
+					}
+					pruned = narrow
+				}
+			}
+			if pruned == nil {
+				continue
+			}
+			if replaced == nil {
+				replaced = make(MapStr, len(record))
+				for name, original := range record {
+					replaced[name] = original
+				}
+			}
+			replaced[field] = pruned
+		}
+		if replaced == nil {
+			continue
+		}
+		if !copied {
+			out = make([]MapStr, len(records))
+			copy(out, records)
+			copied = true
+		}
+		out[idx] = replaced
+	}
+	return out
+}
+
+// ValidateSparseFields validates every sparse vector value held by the records. Values typed as
+// SparseVector, map[string]float64, or map[string]float32 are treated as sparse vectors.
+func ValidateSparseFields(records []MapStr, maxNonZeros int) error {
+	for idx, record := range records {
+		for field, value := range record {
+			var sparse SparseVector
+			switch v := value.(type) {
+			case SparseVector:
+				sparse = v
+			case map[string]float64:
+				sparse = v
+			case map[string]float32:
+				sparse = make(SparseVector, len(v))
+				for key, weight := range v {
+					sparse[key] = float64(weight)
+				}
+			default:
+				continue
+			}
+			if err := sparse.Validate(maxNonZeros); err != nil {
+				return NewErrorWithCause(ErrCodeInvalidParameter, fmt.Sprintf("data[%d].%s holds an invalid sparse vector", idx, field), err, http.StatusBadRequest)
+			}
+		}
+	}
+	return nil
+}
@@ -3,12 +3,49 @@
 
 package vector
 
+import (
+	"context"
+	"net/http"
+)
+
+type forwardHeadersKey struct{}
+
+// ContextWithForwardHeaders stores inbound headers on ctx. Clients configured with WithForwardHeaders
+// copy the named headers from it onto every outgoing request.
+func ContextWithForwardHeaders(ctx context.Context, headers http.Header) context.Context {
+	return context.WithValue(ctx, forwardHeadersKey{}, headers)
+}
+
+// forwardHeadersFromContext returns the headers stored by ContextWithForwardHeaders.
+func forwardHeadersFromContext(ctx context.Context) http.Header {
+	headers, _ := ctx.Value(forwardHeadersKey{}).(http.Header)
+	return headers
+}
+
+type maxRetriesKey struct{}
+
+// ContextWithMaxRetries overrides the client's retry count for every request made with ctx, letting
+// middleware give background jobs and latency-sensitive handlers different policies on one client.
+// Zero disables retries. An explicit WithRequestMaxRetries, including zero, still takes precedence.
+func ContextWithMaxRetries(ctx context.Context, maxRetries int) context.Context {
+	return context.WithValue(ctx, maxRetriesKey{}, maxRetries)
+}
+
+// maxRetriesFromContext returns the retry count stored by ContextWithMaxRetries.
+func maxRetriesFromContext(ctx context.Context) (int, bool) {
+	maxRetries, ok := ctx.Value(maxRetriesKey{}).(int)
+	return maxRetries, ok
+}
+
 // RequestOptions captures per-request overrides for retries, headers, and query params.
 type RequestOptions struct {
 	MaxRetries int
 	Headers    map[string]string
 	Query      map[string]string
 	RequestID  string
+
+	// maxRetriesSet records that WithRequestMaxRetries was applied, so an explicit zero wins too.
+	maxRetriesSet bool
 }
 
 // RequestOption mutates RequestOptions when constructing a request.
@@ -22,21 +59,25 @@ func defaultRequestOptions() *RequestOptions {
 	}
 }
 
-// WithRequestMaxRetries limits the retry count for the current request.
+// WithRequestMaxRetries limits the retry count for the current request, overriding both the client
+// setting and ContextWithMaxRetries. Zero disables retries.
 func WithRequestMaxRetries(maxRetries int) RequestOption {
 	return func(o *RequestOptions) {
 		o.MaxRetries = maxRetries
+		o.maxRetriesSet = true
 	}
 }
 
-// WithRequestHeader sets a single header value for the request.
+// WithRequestHeader sets a single header value for the request. Setting Accept-Encoding turns off
+// net/http's transparent gzip decompression, so compressed responses can no longer be decoded.
 func WithRequestHeader(key, value string) RequestOption {
 	return func(o *RequestOptions) {
 		o.Headers[key] = value
 	}
 }
 
-// WithRequestHeaders merges the provided headers into the request.
+// WithRequestHeaders merges the provided headers into the request. As with WithRequestHeader, an
+// Accept-Encoding header turns off transparent gzip decompression.
 func WithRequestHeaders(headers map[string]string) RequestOption {
 	return func(o *RequestOptions) {
 		if len(headers) == 0 {
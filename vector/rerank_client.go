@@ -6,6 +6,7 @@ package vector
 import (
 	"context"
 	"net/http"
+	"sort"
 
 	"github.com/volcengine/vikingdb-go-sdk/vector/model"
 )
@@ -15,7 +16,61 @@ type rerankClient struct {
 }
 
 func (r *rerankClient) Rerank(ctx context.Context, request model.RerankRequest, opts ...RequestOption) (*model.RerankResponse, error) {
+	if request.ChunkSize < 0 || request.ChunkConcurrency < 0 {
+		return nil, model.NewInvalidParameterError("chunk size and chunk concurrency cannot be negative")
+	}
+	if request.ChunkSize > 0 && len(request.Data) > request.ChunkSize {
+		response, err := r.rerankChunked(ctx, request, request.ChunkSize, opts...)
+		return response, classifyQuotaError(err)
+	}
 	response := &model.RerankResponse{}
 	err := r.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/rerank", request, response, opts...)
-	return response, err
+	return response, classifyQuotaError(err)
+}
+
+// rerankChunked reranks consecutive chunks of Data, at most ChunkConcurrency at a time, and merges the
+// hits by descending score, rewriting each item's ID to its index in the full Data.
+func (r *rerankClient) rerankChunked(ctx context.Context, request model.RerankRequest, chunkSize int, opts ...RequestOption) (*model.RerankResponse, error) {
+	chunkCount := (len(request.Data) + chunkSize - 1) / chunkSize
+	responses := make([]*model.RerankResponse, chunkCount)
+	errs := make([]error, chunkCount)
+	fanOut(chunkCount, request.ChunkConcurrency, func(idx int) {
+		start := idx * chunkSize
+		end := start + chunkSize
+		if end > len(request.Data) {
+			end = len(request.Data)
+		}
+		chunk := request
+		chunk.Data = request.Data[start:end]
+		responses[idx] = &model.RerankResponse{}
+		errs[idx] = r.client.doRequest(ctx, http.MethodPost, "/api/vikingdb/rerank", chunk, responses[idx], opts...)
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	merged := &model.RerankResponse{
+		CommonResponse: responses[0].CommonResponse,
+		Result:         &model.RerankResult{Data: make([]model.RerankItem, 0, len(request.Data))},
+	}
+	usage := make([]interface{}, 0, chunkCount)
+	for idx, resp := range responses {
+		if resp.Result == nil {
+			continue
+		}
+		offset := int64(idx * chunkSize)
+		for _, item := range resp.Result.Data {
+			item.ID += offset
+			merged.Result.Data = append(merged.Result.Data, item)
+		}
+		usage = append(usage, resp.Result.TokenUsage)
+	}
+	merged.Result.TokenUsage = usage
+	sort.SliceStable(merged.Result.Data, func(i, j int) bool {
+		return merged.Result.Data[i].Score > merged.Result.Data[j].Score
+	})
+	return merged, nil
 }
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+func TestRerankChunksAndMergesByScore(t *testing.T) {
+	server := newScriptedServer(t,
+		scriptedReply{http.StatusOK, `{"result":{"data":[{"id":0,"score":0.1},{"id":1,"score":0.9}],"token_usage":{"total_tokens":4}}}`},
+	)
+	client, err := New(AuthAPIKey("test-key"), WithEndpoint(server.URL))
+	require.NoError(t, err)
+
+	text := "candidate"
+	data := rerankCandidates(4)
+	resp, err := client.Rerank().Rerank(context.Background(), model.RerankRequest{
+		ModelName: "rerank",
+		Query:     []model.FullModalData{{Text: &text}},
+		Data:      data,
+		ChunkSize: 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, server.Calls())
+
+	ids := make([]int64, len(resp.Result.Data))
+	for i, item := range resp.Result.Data {
+		ids[i] = item.ID
+	}
+	require.Equal(t, []int64{1, 3, 0, 2}, ids)
+	require.Len(t, resp.Result.TokenUsage, 2)
+}
+
+func rerankCandidates(n int) [][]model.FullModalData {
+	text := "candidate"
+	data := make([][]model.FullModalData, n)
+	for i := range data {
+		data[i] = []model.FullModalData{{Text: &text}}
+	}
+	return data
+}
+
+func TestRerankWithoutChunkSizeSendsOneCall(t *testing.T) {
+	server := newScriptedServer(t, scriptedReply{http.StatusOK, `{"result":{"data":[{"id":0,"score":0.5}]}}`})
+	text := "query"
+	_, err := newTestClient(t, server.URL).Rerank().Rerank(context.Background(), model.RerankRequest{
+		ModelName: "rerank",
+		Query:     []model.FullModalData{{Text: &text}},
+		Data:      rerankCandidates(120),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, server.Calls())
+}
+
+func TestRerankChunkConcurrencyIsBounded(t *testing.T) {
+	var inFlight, peak, calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		now := atomic.AddInt32(&inFlight, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if now <= old || atomic.CompareAndSwapInt32(&peak, old, now) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		_, _ = w.Write([]byte(`{"result":{"data":[{"id":0,"score":0.5}]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	text := "query"
+	_, err := newTestClient(t, server.URL).Rerank().Rerank(context.Background(), model.RerankRequest{
+		ModelName:        "rerank",
+		Query:            []model.FullModalData{{Text: &text}},
+		Data:             rerankCandidates(10),
+		ChunkSize:        1,
+		ChunkConcurrency: 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(10), atomic.LoadInt32(&calls))
+	require.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
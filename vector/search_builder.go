@@ -0,0 +1,292 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import "github.com/volcengine/vikingdb-go-sdk/vector/model"
+
+// SearchBuilder assembles the settings shared by every search. Set them first, then pick the query
+// modality with Vector, Text, Image, Video, Keywords, or Query and call Build on the result:
+//
+//	req := vector.NewSearch().Filter(f).Limit(10).Output("title").DenseWeight(0.7).Vector(v).Build()
+//
+// Base returns the shared settings for requests without a modality, such as SearchByScalarRequest.
+type SearchBuilder struct {
+	base model.SearchBase
+}
+
+// NewSearch starts a search request.
+func NewSearch() *SearchBuilder {
+	return &SearchBuilder{}
+}
+
+// Filter sets the scalar filter.
+func (b *SearchBuilder) Filter(filter model.MapStr) *SearchBuilder {
+	b.base.Filter = filter
+	return b
+}
+
+// Partition restricts the search to one partition.
+func (b *SearchBuilder) Partition(partition string) *SearchBuilder {
+	b.base.Partition = partition
+	return b
+}
+
+// Limit sets the number of hits to return.
+func (b *SearchBuilder) Limit(n int) *SearchBuilder {
+	b.base.Limit = &n
+	return b
+}
+
+// Offset skips the first n hits.
+func (b *SearchBuilder) Offset(n int) *SearchBuilder {
+	b.base.Offset = &n
+	return b
+}
+
+// Output appends fields to return with each hit.
+func (b *SearchBuilder) Output(fields ...string) *SearchBuilder {
+	b.base.OutputFields = append(b.base.OutputFields, fields...)
+	return b
+}
+
+// DenseWeight sets the dense share of hybrid scoring, within [0, 1].
+func (b *SearchBuilder) DenseWeight(w float64) *SearchBuilder {
+	b.advance().DenseWeight = &w
+	return b
+}
+
+// IDsIn restricts recall to the listed primary keys.
+func (b *SearchBuilder) IDsIn(ids ...interface{}) *SearchBuilder {
+	advance := b.advance()
+	advance.IDsIn = append(advance.IDsIn, ids...)
+	return b
+}
+
+// IDsNotIn excludes the listed primary keys.
+func (b *SearchBuilder) IDsNotIn(ids ...interface{}) *SearchBuilder {
+	advance := b.advance()
+	advance.IDsNotIn = append(advance.IDsNotIn, ids...)
+	return b
+}
+
+func (b *SearchBuilder) advance() *model.SearchAdvance {
+	if b.base.Advance == nil {
+		b.base.Advance = &model.SearchAdvance{}
+	}
+	return b.base.Advance
+}
+
+// Base returns a copy of the shared settings built so far.
+func (b *SearchBuilder) Base() model.SearchBase {
+	base := b.base
+	base.OutputFields = append([]string(nil), b.base.OutputFields...)
+	if b.base.Advance != nil {
+		advance := *b.base.Advance
+		advance.IDsIn = append([]interface{}(nil), b.base.Advance.IDsIn...)
+		advance.IDsNotIn = append([]interface{}(nil), b.base.Advance.IDsNotIn...)
+		base.Advance = &advance
+	}
+	return base
+}
+
+// Vector queries by dense vector.
+func (b *SearchBuilder) Vector(dense []float64) *VectorSearchBuilder {
+	return &VectorSearchBuilder{request: model.SearchByVectorRequest{SearchBase: b.Base(), DenseVector: dense}}
+}
+
+// VectorF32 queries by a float32 dense vector, such as an Embedding result, without converting it.
+func (b *SearchBuilder) VectorF32(dense []float32) *VectorSearchBuilder {
+	return &VectorSearchBuilder{request: model.SearchByVectorRequest{SearchBase: b.Base(), DenseVectorF32: dense}}
+}
+
+// Text queries a vectorize index by text.
+func (b *SearchBuilder) Text(text string) *MultiModalSearchBuilder {
+	return b.multiModal().Text(text)
+}
+
+// Image queries a vectorize index by image.
+func (b *SearchBuilder) Image(image interface{}) *MultiModalSearchBuilder {
+	return b.multiModal().Image(image)
+}
+
+// Video queries a vectorize index by video.
+func (b *SearchBuilder) Video(video interface{}) *MultiModalSearchBuilder {
+	return b.multiModal().Video(video)
+}
+
+// Keywords matches documents containing the keywords.
+func (b *SearchBuilder) Keywords(keywords ...string) *KeywordsSearchBuilder {
+	return b.keywords().Keywords(keywords...)
+}
+
+// Query matches documents against a free-text keyword query.
+func (b *SearchBuilder) Query(query string) *KeywordsSearchBuilder {
+	return b.keywords().Query(query)
+}
+
+func (b *SearchBuilder) multiModal() *MultiModalSearchBuilder {
+	return &MultiModalSearchBuilder{request: model.SearchByMultiModalRequest{SearchBase: b.Base()}}
+}
+
+func (b *SearchBuilder) keywords() *KeywordsSearchBuilder {
+	return &KeywordsSearchBuilder{request: model.SearchByKeywordsRequest{SearchBase: b.Base()}}
+}
+
+// VectorSearchBuilder completes a SearchByVectorRequest.
+type VectorSearchBuilder struct {
+	request model.SearchByVectorRequest
+}
+
+// Sparse adds a sparse vector for hybrid search.
+func (b *VectorSearchBuilder) Sparse(sparse model.SparseVector) *VectorSearchBuilder {
+	b.request.SparseVector = sparse
+	return b
+}
+
+// Metric sets the query-time distance metric sent with the search; see SearchByVectorRequest.Metric.
+func (b *VectorSearchBuilder) Metric(metric string) *VectorSearchBuilder {
+	b.request.Metric = &metric
+	return b
+}
+
+// Build returns the request.
+func (b *VectorSearchBuilder) Build() model.SearchByVectorRequest {
+	return b.request
+}
+
+// MultiModalSearchBuilder completes a SearchByMultiModalRequest.
+type MultiModalSearchBuilder struct {
+	request model.SearchByMultiModalRequest
+}
+
+// Text sets the text part of the query.
+func (b *MultiModalSearchBuilder) Text(text string) *MultiModalSearchBuilder {
+	b.request.Text = &text
+	return b
+}
+
+// Image sets the image part of the query.
+func (b *MultiModalSearchBuilder) Image(image interface{}) *MultiModalSearchBuilder {
+	b.request.Image = image
+	return b
+}
+
+// Video sets the video part of the query.
+func (b *MultiModalSearchBuilder) Video(video interface{}) *MultiModalSearchBuilder {
+	b.request.Video = video
+	return b
+}
+
+// NeedInstruction toggles the server-side query instruction of the vectorize model.
+func (b *MultiModalSearchBuilder) NeedInstruction(need bool) *MultiModalSearchBuilder {
+	b.request.NeedInstruction = &need
+	return b
+}
+
+// Build returns the request.
+func (b *MultiModalSearchBuilder) Build() model.SearchByMultiModalRequest {
+	return b.request
+}
+
+// KeywordsSearchBuilder completes a SearchByKeywordsRequest.
+type KeywordsSearchBuilder struct {
+	request model.SearchByKeywordsRequest
+}
+
+// Keywords appends keywords to match.
+func (b *KeywordsSearchBuilder) Keywords(keywords ...string) *KeywordsSearchBuilder {
+	b.request.Keywords = append(b.request.Keywords, keywords...)
+	return b
+}
+
+// Query sets the free-text keyword query.
+func (b *KeywordsSearchBuilder) Query(query string) *KeywordsSearchBuilder {
+	b.request.Query = query
+	return b
+}
+
+// CaseSensitive toggles case-sensitive matching.
+func (b *KeywordsSearchBuilder) CaseSensitive(sensitive bool) *KeywordsSearchBuilder {
+	b.request.CaseSensitive = sensitive
+	return b
+}
+
+// FieldBoost weights matches in field by boost.
+func (b *KeywordsSearchBuilder) FieldBoost(field string, boost float64) *KeywordsSearchBuilder {
+	if b.request.FieldBoosts == nil {
+		b.request.FieldBoosts = make(map[string]float64)
+	}
+	b.request.FieldBoosts[field] = boost
+	return b
+}
+
+// Build returns the request.
+func (b *KeywordsSearchBuilder) Build() model.SearchByKeywordsRequest {
+	return b.request
+}
+
+// HybridQueryBuilder assembles a dense-plus-sparse SearchByVectorRequest from embedding output, such
+// as the Dense and Sparse vectors a bge-m3 model returns:
+//
+//	emb := resp.Result.Data[0]
+//	req := vector.NewHybridQuery(emb.DenseVectors, emb.SparseVectors).Filter(f).Limit(10).Build()
+type HybridQueryBuilder struct {
+	search *SearchBuilder
+	dense  []float32
+	sparse map[string]float32
+}
+
+// NewHybridQuery starts a hybrid query. The dense vector is sent as is through DenseVectorF32; the
+// sparse weights are widened to a model.SparseVector.
+func NewHybridQuery(dense []float32, sparse map[string]float32) *HybridQueryBuilder {
+	return &HybridQueryBuilder{search: NewSearch(), dense: dense, sparse: sparse}
+}
+
+// Filter sets the scalar filter.
+func (b *HybridQueryBuilder) Filter(filter model.MapStr) *HybridQueryBuilder {
+	b.search.Filter(filter)
+	return b
+}
+
+// Partition restricts the search to one partition.
+func (b *HybridQueryBuilder) Partition(partition string) *HybridQueryBuilder {
+	b.search.Partition(partition)
+	return b
+}
+
+// Limit sets the number of hits to return.
+func (b *HybridQueryBuilder) Limit(n int) *HybridQueryBuilder {
+	b.search.Limit(n)
+	return b
+}
+
+// Offset skips the first n hits.
+func (b *HybridQueryBuilder) Offset(n int) *HybridQueryBuilder {
+	b.search.Offset(n)
+	return b
+}
+
+// Output appends fields to return with each hit.
+func (b *HybridQueryBuilder) Output(fields ...string) *HybridQueryBuilder {
+	b.search.Output(fields...)
+	return b
+}
+
+// DenseWeight sets the dense share of hybrid scoring, within [0, 1].
+func (b *HybridQueryBuilder) DenseWeight(w float64) *HybridQueryBuilder {
+	b.search.DenseWeight(w)
+	return b
+}
+
+// Build returns the request.
+func (b *HybridQueryBuilder) Build() model.SearchByVectorRequest {
+	var sparse model.SparseVector
+	if len(b.sparse) > 0 {
+		sparse = make(model.SparseVector, len(b.sparse))
+		for key, weight := range b.sparse {
+			sparse[key] = float64(weight)
+		}
+	}
+	return b.search.VectorF32(b.dense).Sparse(sparse).Build()
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+func TestHybridQueryBuild(t *testing.T) {
+	filter := model.MapStr{model.FilterKeyOp: model.OpMust, model.FilterKeyField: "lang", "conds": []interface{}{"en"}}
+	req := NewHybridQuery([]float32{0.5, -0.25}, map[string]float32{"vector": 0.75, "db": 0.5}).
+		Filter(filter).
+		Limit(5).
+		DenseWeight(0.6).
+		Build()
+
+	require.NoError(t, req.Validate())
+	require.Equal(t, []float32{0.5, -0.25}, req.DenseVectorF32)
+	require.Empty(t, req.DenseVector)
+	require.Equal(t, model.SparseVector{"vector": 0.75, "db": 0.5}, req.SparseVector)
+	require.Equal(t, 5, *req.Limit)
+	require.Equal(t, 0.6, *req.Advance.DenseWeight)
+	require.Equal(t, filter, req.Filter)
+
+	require.Nil(t, NewHybridQuery([]float32{1}, nil).Build().SparseVector)
+}
@@ -4,29 +4,84 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"syscall"
 
 	"github.com/volcengine/vikingdb-go-sdk/vector/model"
 )
 
-// DoHTTPRequest executes the HTTP request and wraps transport errors in an SDK error.
+// DoHTTPRequest executes the HTTP request and wraps transport errors in an SDK error: timeouts become
+// ErrCodeTimeout, failures to reach the server, including refused or dropped connections and DNS
+// lookups, ErrCodeServiceUnavailable, and anything else, such as a cancelled context or a malformed
+// endpoint address, ErrCodeHTTPRequestFailed. No response was received, so StatusCode is left at 0 and
+// model.IsRetryableError decides by code: the first two are retried, the last is not. The original
+// error is kept as the cause.
 func DoHTTPRequest(client *http.Client, req *http.Request) (*http.Response, error) {
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, model.NewErrorWithCause(model.ErrCodeHTTPRequestFailed, "failed to execute http request", err, http.StatusServiceUnavailable)
+		return nil, classifyTransportError(err)
 	}
 	return resp, nil
 }
 
-// ParseResponse reads the HTTP response body, decoding JSON into result when provided.
+func classifyTransportError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return model.NewErrorWithCause(model.ErrCodeHTTPRequestFailed, "http request canceled", err, 0)
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return model.NewErrorWithCause(model.ErrCodeTimeout, "http request timed out", err, 0)
+	}
+	var addrErr *net.AddrError
+	var parseErr *net.ParseError
+	var networkErr net.UnknownNetworkError
+	if errors.As(err, &addrErr) || errors.As(err, &parseErr) || errors.As(err, &networkErr) {
+		return model.NewErrorWithCause(model.ErrCodeHTTPRequestFailed, "invalid endpoint address", err, 0)
+	}
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return model.NewErrorWithCause(model.ErrCodeServiceUnavailable, "failed to connect to server", err, 0)
+	}
+	return model.NewErrorWithCause(model.ErrCodeHTTPRequestFailed, "failed to execute http request", err, 0)
+}
+
+// Decoder decodes a JSON payload into target.
+type Decoder func(input []byte, target interface{}) error
+
+// ParseResponse reads the HTTP response body, decoding JSON into result when provided. net/http
+// advertises gzip and inflates compressed responses before they get here, unless the request set its
+// own Accept-Encoding header, in which case the body stays compressed and cannot be decoded.
 func ParseResponse(resp *http.Response, result interface{}) error {
+	return ParseResponseWithDecoder(resp, result, ParseJSONUseNumber)
+}
+
+// ParseResponseWithDecoder behaves like ParseResponse but decodes a successful body with decode.
+func ParseResponseWithDecoder(resp *http.Response, result interface{}, decode Decoder) error {
+	body, err := ReadBody(resp)
+	if err != nil {
+		return err
+	}
+	return ParseBody(resp, body, result, decode)
+}
+
+// ReadBody reads the whole response body.
+func ReadBody(resp *http.Response) ([]byte, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return model.NewErrorWithCause(model.ErrCodeUnknown, "failed to read response body", err, http.StatusInternalServerError)
+		return nil, model.NewErrorWithCause(model.ErrCodeUnknown, "failed to read response body", err, http.StatusInternalServerError)
 	}
+	return body, nil
+}
 
+// ParseBody interprets a body already read from resp the way ParseResponseWithDecoder does.
+func ParseBody(resp *http.Response, body []byte, result interface{}, decode Decoder) error {
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 		var errResp struct {
 			Code      string `json:"code"`
@@ -36,14 +91,17 @@ func ParseResponse(resp *http.Response, result interface{}) error {
 		if parseErr := ParseJSONUseNumber(body, &errResp); parseErr == nil && (errResp.Code != "" || errResp.Message != "") {
 			return model.NewErrorWithRequestID(model.ErrorCode(errResp.Code), errResp.Message, errResp.RequestID, resp.StatusCode)
 		}
-		return model.NewErrorWithCause(model.ErrCodeUnknown, fmt.Sprintf("unexpected %d response: %s", resp.StatusCode, string(body)), err, resp.StatusCode)
+		return model.NewErrorWithCause(model.ErrCodeUnknown, fmt.Sprintf("unexpected %d response: %s", resp.StatusCode, string(body)), nil, resp.StatusCode)
 	}
 
 	if result == nil || len(body) == 0 {
 		return nil
 	}
 
-	if err := ParseJSONUseNumber(body, result); err != nil {
+	if decode == nil {
+		decode = ParseJSONUseNumber
+	}
+	if err := decode(body, result); err != nil {
 		return model.NewErrorWithCause(model.ErrCodeUnknown, "failed to unmarshal response body", err, resp.StatusCode)
 	}
 
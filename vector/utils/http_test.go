@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
+)
+
+// newGzipServer always answers with a gzip-encoded JSON body and records the Accept-Encoding it saw.
+func newGzipServer(t *testing.T, acceptEncoding *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte(`{"request_id":"req-gzip","result":{"total":3}}`))
+		_ = gz.Close()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+type gzipResult struct {
+	RequestID string `json:"request_id"`
+	Result    struct {
+		Total int `json:"total"`
+	} `json:"result"`
+}
+
+func TestParseResponseReadsGzipNegotiatedByTransport(t *testing.T) {
+	var acceptEncoding string
+	server := newGzipServer(t, &acceptEncoding)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := DoHTTPRequest(server.Client(), req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, "gzip", acceptEncoding, "net/http advertises gzip on its own")
+	require.True(t, resp.Uncompressed, "net/http inflates the body before the SDK reads it")
+	var result gzipResult
+	require.NoError(t, ParseResponse(resp, &result))
+	require.Equal(t, "req-gzip", result.RequestID)
+	require.Equal(t, 3, result.Result.Total)
+}
+
+func TestCallerAcceptEncodingDisablesTransparentGzip(t *testing.T) {
+	var acceptEncoding string
+	server := newGzipServer(t, &acceptEncoding)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := DoHTTPRequest(server.Client(), req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.False(t, resp.Uncompressed)
+	var result gzipResult
+	require.Error(t, ParseResponse(resp, &result), "the body is left compressed when the caller set Accept-Encoding")
+}
+
+func TestClassifyTransportError(t *testing.T) {
+	dial := func(err error) error { return &net.OpError{Op: "dial", Net: "tcp", Err: err} }
+	cases := []struct {
+		name      string
+		err       error
+		code      model.ErrorCode
+		retryable bool
+	}{
+		{"connection refused", dial(os.NewSyscallError("connect", syscall.ECONNREFUSED)), model.ErrCodeServiceUnavailable, true},
+		{"host unreachable", dial(os.NewSyscallError("connect", syscall.EHOSTUNREACH)), model.ErrCodeServiceUnavailable, true},
+		{"network unreachable", dial(os.NewSyscallError("connect", syscall.ENETUNREACH)), model.ErrCodeServiceUnavailable, true},
+		{"host not found", dial(&net.DNSError{Err: "no such host", Name: "vikingdb.example", IsNotFound: true}), model.ErrCodeServiceUnavailable, true},
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, model.ErrCodeServiceUnavailable, true},
+		{"connection closed", io.EOF, model.ErrCodeServiceUnavailable, true},
+		{"timeout", dial(&net.DNSError{Err: "i/o timeout", IsTimeout: true}), model.ErrCodeTimeout, true},
+		{"deadline", context.DeadlineExceeded, model.ErrCodeTimeout, true},
+		{"canceled", context.Canceled, model.ErrCodeHTTPRequestFailed, false},
+		{"missing port", dial(&net.AddrError{Err: "missing port in address", Addr: "vikingdb"}), model.ErrCodeHTTPRequestFailed, false},
+		{"other", errors.New("unsupported protocol scheme"), model.ErrCodeHTTPRequestFailed, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := classifyTransportError(tc.err)
+			var sdkErr *model.Error
+			require.True(t, errors.As(err, &sdkErr))
+			require.Equal(t, tc.code, sdkErr.Code)
+			require.Zero(t, sdkErr.StatusCode, "no response was received")
+			require.Equal(t, tc.retryable, model.IsRetryableError(err))
+			require.ErrorIs(t, err, tc.err, "the transport error is kept as the cause")
+		})
+	}
+}
+
+func TestDoHTTPRequestRefusedConnectionIsRetryable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+addr+"/", nil)
+	require.NoError(t, err)
+	_, err = DoHTTPRequest(http.DefaultClient, req)
+	var sdkErr *model.Error
+	require.True(t, errors.As(err, &sdkErr))
+	require.Equal(t, model.ErrCodeServiceUnavailable, sdkErr.Code)
+	require.True(t, model.IsRetryableError(err))
+}
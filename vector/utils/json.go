@@ -7,6 +7,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/volcengine/vikingdb-go-sdk/vector/model"
 )
 
 // ParseJSONUseNumber decodes input into target while preserving numeric precision via json.Number.
@@ -19,7 +23,78 @@ func ParseJSONUseNumber(input []byte, target interface{}) error {
 	return decoder.Decode(target)
 }
 
+// ParseJSON decodes input into target, decoding numbers as float64.
+func ParseJSON(input []byte, target interface{}) error {
+	if target == nil {
+		return errors.New("ParseJSON: target must not be nil")
+	}
+	return json.Unmarshal(input, target)
+}
+
 // SerializeToJSON marshals the provided value into JSON bytes.
 func SerializeToJSON(source interface{}) ([]byte, error) {
 	return json.Marshal(source)
 }
+
+// CaptureUnknownFields wraps decode so targets implementing SetExtra(model.MapStr) also receive the
+// top-level keys of the payload that their struct does not declare.
+func CaptureUnknownFields(decode Decoder) Decoder {
+	return func(input []byte, target interface{}) error {
+		if err := decode(input, target); err != nil {
+			return err
+		}
+		recorder, ok := target.(interface{ SetExtra(model.MapStr) })
+		if !ok {
+			return nil
+		}
+		var raw map[string]interface{}
+		if err := ParseJSONUseNumber(input, &raw); err != nil {
+			// Non-object payloads carry no top-level keys to capture.
+			return nil
+		}
+		known := make(map[string]struct{})
+		collectJSONNames(reflect.TypeOf(target), known)
+		var extra model.MapStr
+		for key, value := range raw {
+			if _, ok := known[strings.ToLower(key)]; ok {
+				continue
+			}
+			if extra == nil {
+				extra = make(model.MapStr)
+			}
+			extra[key] = value
+		}
+		recorder.SetExtra(extra)
+		return nil
+	}
+}
+
+// collectJSONNames records the lower-cased JSON keys of t's fields, following embedded structs the
+// way encoding/json does.
+func collectJSONNames(t reflect.Type, names map[string]struct{}) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			collectJSONNames(field.Type, names)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[strings.ToLower(name)] = struct{}{}
+	}
+}
@@ -4,7 +4,9 @@
 package utils
 
 import (
+	"context"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/volcengine/vikingdb-go-sdk/vector/model"
@@ -16,23 +18,92 @@ const (
 	backoffMultiplier     = 2.0
 )
 
+// JitterStrategy selects how randomness is added to each backoff delay.
+type JitterStrategy int
+
+const (
+	// FullJitter sleeps for the delay plus a random amount up to the full delay.
+	FullJitter JitterStrategy = iota
+	// EqualJitter sleeps for half the delay plus a random amount up to the other half.
+	EqualJitter
+	// NoJitter sleeps for exactly the delay.
+	NoJitter
+)
+
+// RandSource yields the random jitter values used by RetryWithOptions.
+type RandSource interface {
+	Int63n(n int64) int64
+}
+
+// LockedRand makes a *rand.Rand safe for concurrent use.
+type LockedRand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// NewLockedRand wraps r, or a source seeded from the current time when r is nil.
+func NewLockedRand(r *rand.Rand) *LockedRand {
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return &LockedRand{r: r}
+}
+
+// Int63n returns a non-negative pseudo-random number in [0,n).
+func (l *LockedRand) Int63n(n int64) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Int63n(n)
+}
+
+// RetryOptions configures RetryWithOptions.
+type RetryOptions struct {
+	MaxRetries int
+	Jitter     JitterStrategy
+	// Rand supplies jitter; the global math/rand source is used when nil.
+	Rand RandSource
+	// OnRetry, when set, is called before each backoff with the retry number (starting at 1), the
+	// error being retried, and the delay about to be slept.
+	OnRetry func(attempt int, err error, nextDelay time.Duration)
+	// MaxElapsed, when positive, stops retrying once another backoff would take the time since the
+	// first attempt past it; the last error is returned.
+	MaxElapsed time.Duration
+	// Context, when set, stops retrying once it is done: a failed attempt is not retried and a pending
+	// backoff ends early, returning the last error.
+	Context context.Context
+}
+
 // Retry executes fn with exponential backoff. Retries stop when fn returns nil, the max retry count is reached,
 // or shouldRetry returns false for the latest error.
 func Retry(maxRetries int, fn func() error, shouldRetry func(error) bool) error {
+	return RetryWithOptions(RetryOptions{MaxRetries: maxRetries}, fn, shouldRetry)
+}
+
+// RetryWithOptions behaves like Retry with configurable backoff behavior.
+func RetryWithOptions(opts RetryOptions, fn func() error, shouldRetry func(error) bool) error {
+	maxRetries := opts.MaxRetries
 	if maxRetries < 0 {
 		maxRetries = 0
 	}
 	var lastErr error
 	delay := defaultInitialBackoff
+	start := time.Now()
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			jitter := time.Duration(rand.Int63n(int64(delay)))
-			sleepFor := delay + jitter
+			sleepFor := backoffDelay(delay, opts.Jitter, opts.Rand)
 			if sleepFor > defaultMaxBackoff {
 				sleepFor = defaultMaxBackoff
 			}
-			time.Sleep(sleepFor)
+			if opts.MaxElapsed > 0 && time.Since(start)+sleepFor > opts.MaxElapsed {
+				return lastErr
+			}
+			if opts.OnRetry != nil {
+				opts.OnRetry(attempt, lastErr, sleepFor)
+			}
+			if !sleepContext(opts.Context, sleepFor) {
+				return lastErr
+			}
 			next := time.Duration(float64(delay) * backoffMultiplier)
 			if next > defaultMaxBackoff {
 				next = defaultMaxBackoff
@@ -45,6 +116,9 @@ func Retry(maxRetries int, fn func() error, shouldRetry func(error) bool) error
 			if shouldRetry != nil && !shouldRetry(err) {
 				return err
 			}
+			if opts.Context != nil && opts.Context.Err() != nil {
+				return err
+			}
 			if attempt == maxRetries {
 				return err
 			}
@@ -56,6 +130,42 @@ func Retry(maxRetries int, fn func() error, shouldRetry func(error) bool) error
 	return lastErr
 }
 
+// sleepContext sleeps for d, returning false if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if ctx == nil {
+		time.Sleep(d)
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// backoffDelay applies the jitter strategy to the base delay.
+func backoffDelay(delay time.Duration, strategy JitterStrategy, source RandSource) time.Duration {
+	int63n := rand.Int63n
+	if source != nil {
+		int63n = source.Int63n
+	}
+	switch strategy {
+	case NoJitter:
+		return delay
+	case EqualJitter:
+		half := delay / 2
+		if half <= 0 {
+			return delay
+		}
+		return half + time.Duration(int63n(int64(half)))
+	default:
+		return delay + time.Duration(int63n(int64(delay)))
+	}
+}
+
 // IsRetryableError delegates to model.IsRetryableError for backward compatibility.
 func IsRetryableError(err error) bool {
 	return model.IsRetryableError(err)
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Beijing Volcano Engine Technology Co., Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoffDelayJitterBounds(t *testing.T) {
+	source := NewLockedRand(rand.New(rand.NewSource(1)))
+	delay := 100 * time.Millisecond
+
+	for i := 0; i < 200; i++ {
+		require.Equal(t, delay, backoffDelay(delay, NoJitter, source))
+
+		equal := backoffDelay(delay, EqualJitter, source)
+		require.GreaterOrEqual(t, equal, delay/2)
+		require.Less(t, equal, delay)
+
+		full := backoffDelay(delay, FullJitter, source)
+		require.GreaterOrEqual(t, full, delay)
+		require.Less(t, full, 2*delay)
+	}
+	require.Equal(t, time.Duration(1), backoffDelay(1, EqualJitter, source), "a delay too small to halve is used as is")
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	retryable := func(error) bool { return true }
+	failure := errors.New("unavailable")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := RetryWithOptions(RetryOptions{MaxRetries: 5, Jitter: NoJitter, Context: ctx}, func() error {
+		calls++
+		cancel()
+		return failure
+	}, retryable)
+	require.Equal(t, failure, err)
+	require.Equal(t, 1, calls, "a failure after cancellation is not retried")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	calls = 0
+	start := time.Now()
+	err = RetryWithOptions(RetryOptions{MaxRetries: 5, Jitter: NoJitter, Context: ctx, OnRetry: func(int, error, time.Duration) {
+		cancel()
+	}}, func() error {
+		calls++
+		return failure
+	}, retryable)
+	require.Equal(t, failure, err)
+	require.Equal(t, 1, calls, "cancellation during backoff ends the retries")
+	require.Less(t, int64(time.Since(start)), int64(defaultInitialBackoff), "the backoff sleep ends early")
+}